@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ==================== AGGREGATION ====================
+//
+// GET /api/aggregate?group_by=egg,type&metrics=count,avg(spawn_chance),p90(weight)&filter=...
+// type/weaknesses এর মতো বহুমানের কী তে একটি রেকর্ড তার প্রতিটি মানের গ্রুপে গোনা হয়।
+
+// মেট্রিকে ব্যবহারযোগ্য সংখ্যার ফিল্ড; height/weight পার্স করা মিটার/কেজি
+var metricFields = []string{"num", "spawn_chance", "avg_spawns", "candy_count", "height", "weight", "base_attack", "base_defense", "base_stamina"}
+
+var metricPattern = regexp.MustCompile(`^(count|sum|avg|min|max|median|p([0-9]{1,2}))\(([a-z_]+)\)$`)
+
+type metric struct {
+	name  string  // রেসপন্সের কী, যেমন "avg(spawn_chance)"
+	op    string  // count, sum, avg, min, max, percentile
+	field string  // count এর জন্য খালি হতে পারে
+	pct   float64 // percentile এর জন্য 0-100
+}
+
+func parseMetrics(s string) ([]metric, error) {
+	if strings.TrimSpace(s) == "" {
+		return []metric{{name: "count", op: "count"}}, nil
+	}
+
+	var metrics []metric
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ToLower(strings.ReplaceAll(part, " ", ""))
+		if name == "" {
+			return nil, fmt.Errorf("empty metric")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("metric %q given more than once", name)
+		}
+		seen[name] = true
+
+		if name == "count" {
+			metrics = append(metrics, metric{name: name, op: "count"})
+			continue
+		}
+
+		m := metricPattern.FindStringSubmatch(name)
+		if m == nil {
+			return nil, fmt.Errorf("invalid metric %q, expected count or one of sum, avg, min, max, median, p<N> applied to a field, e.g. avg(spawn_chance)", name)
+		}
+		if !isMetricField(m[3]) {
+			return nil, fmt.Errorf("unknown metric field %q, expected one of %s", m[3], strings.Join(metricFields, ", "))
+		}
+
+		mt := metric{name: name, op: m[1], field: m[3]}
+		switch {
+		case m[1] == "median":
+			mt.op, mt.pct = "percentile", 50
+		case m[2] != "":
+			mt.op = "percentile"
+			mt.pct, _ = strconv.ParseFloat(m[2], 64)
+		}
+		metrics = append(metrics, mt)
+	}
+	return metrics, nil
+}
+
+func isMetricField(name string) bool {
+	for _, f := range metricFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGroupBy(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range strings.Split(s, ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := facetFields[key]; !ok {
+			return nil, fmt.Errorf("unknown group key %q, expected one of %s", key, facetNames())
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("group key %q given more than once", key)
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// একটি রেকর্ডের সব গ্রুপ কী এর সমন্বয়; মান না থাকলে সেই কী null
+func groupCombinations(p *Pokemon, keys []string) [][]*string {
+	combos := [][]*string{{}}
+	for _, key := range keys {
+		values := uniqueStrings(facetFields[key](p))
+		var next [][]*string
+		for _, combo := range combos {
+			if len(values) == 0 {
+				next = append(next, append(combo[:len(combo):len(combo)], nil))
+				continue
+			}
+			for i := range values {
+				next = append(next, append(combo[:len(combo):len(combo)], &values[i]))
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+type aggregateBucket struct {
+	key     []*string
+	members []*Pokemon
+}
+
+func aggregate(list []Pokemon, keys []string, metrics []metric) []AggregateGroup {
+	buckets := make(map[string]*aggregateBucket)
+	var order []*aggregateBucket
+	for i := range list {
+		for _, combo := range groupCombinations(&list[i], keys) {
+			id := groupID(combo)
+			b := buckets[id]
+			if b == nil {
+				b = &aggregateBucket{key: combo}
+				buckets[id] = b
+				order = append(order, b)
+			}
+			b.members = append(b.members, &list[i])
+		}
+	}
+
+	// কী অনুযায়ী সাজানো, null শেষে
+	sort.SliceStable(order, func(i, j int) bool {
+		for k := range keys {
+			a, b := order[i].key[k], order[j].key[k]
+			switch {
+			case a == nil && b == nil:
+				continue
+			case a == nil:
+				return false
+			case b == nil:
+				return true
+			case *a != *b:
+				return *a < *b
+			}
+		}
+		return false
+	})
+
+	groups := make([]AggregateGroup, 0, len(order))
+	for _, b := range order {
+		key := make(map[string]interface{}, len(keys))
+		for k, name := range keys {
+			if b.key[k] == nil {
+				key[name] = nil
+			} else {
+				key[name] = *b.key[k]
+			}
+		}
+
+		values := make(map[string]interface{}, len(metrics))
+		for _, m := range metrics {
+			values[m.name] = m.compute(b.members)
+		}
+		groups = append(groups, AggregateGroup{Key: key, Count: len(b.members), Metrics: values})
+	}
+	return groups
+}
+
+func groupID(combo []*string) string {
+	var b strings.Builder
+	for _, v := range combo {
+		if v == nil {
+			b.WriteString("\x00null")
+		} else {
+			b.WriteString("\x00" + *v)
+		}
+	}
+	return b.String()
+}
+
+// মান না থাকা রেকর্ড বাদ দিয়ে হিসাব; কোনো মান না থাকলে null
+func (m metric) compute(members []*Pokemon) interface{} {
+	if m.op == "count" && m.field == "" {
+		return len(members)
+	}
+
+	values := metricValues(members, m.field)
+	if m.op == "count" {
+		return len(values)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	switch m.op {
+	case "sum":
+		return roundMetric(sum(values))
+	case "avg":
+		return roundMetric(sum(values) / float64(len(values)))
+	case "min":
+		return values[0]
+	case "max":
+		return values[len(values)-1]
+	default:
+		return roundMetric(percentile(values, m.pct))
+	}
+}
+
+// ফিল্ডের উপস্থিত মানগুলো, ছোট থেকে বড়
+func metricValues(members []*Pokemon, field string) []float64 {
+	value := sortFields[field]
+	values := make([]float64, 0, len(members))
+	for _, p := range members {
+		if v, ok := value(p); ok {
+			values = append(values, v.(float64))
+		}
+	}
+	sort.Float64s(values)
+	return values
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// সাজানো মানের উপর লিনিয়ার ইন্টারপোলেশন সহ পার্সেন্টাইল (p: 0-100)
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+func roundMetric(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
+
+// GET /api/aggregate
+func aggregatePokemons(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	invalid := func(field string, err error) {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid aggregation",
+			Details: []ValidationError{{Field: field, Message: err.Error()}},
+		})
+	}
+
+	keys, err := parseGroupBy(query.Get("group_by"))
+	if err != nil {
+		invalid("group_by", err)
+		return
+	}
+	metrics, err := parseMetrics(query.Get("metrics"))
+	if err != nil {
+		invalid("metrics", err)
+		return
+	}
+	filter, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		invalid("filter", err)
+		return
+	}
+
+	var filters []filterExpr
+	if filter != nil {
+		filters = append(filters, filter)
+	}
+
+	db.rlockCtx(r.Context())
+	list := selectPokemons(db.pokemons, filters)
+	db.RUnlock()
+
+	if keys == nil {
+		keys = []string{}
+	}
+	names := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		names = append(names, m.name)
+	}
+
+	respondJSON(w, http.StatusOK, AggregateResponse{
+		GroupBy: keys,
+		Metrics: names,
+		Total:   len(list),
+		Groups:  aggregate(list, keys, metrics),
+	})
+}
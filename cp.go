@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// ==================== CP / IV ====================
+//
+// Pokémon GO এর সূত্র:
+//
+//	CP = floor((A + iv.A) × √(D + iv.D) × √(S + iv.S) × CPM² / 10), কমপক্ষে 10
+//	HP = floor((S + iv.S) × CPM), কমপক্ষে 10
+//
+// A/D/S রেকর্ডের base_attack/base_defense/base_stamina, IV প্রতিটি 0-15,
+// CPM লেভেল অনুযায়ী (1 থেকে 50, আধা লেভেলসহ)।
+
+const (
+	minLevel = 1.0
+	maxLevel = 50.0
+	maxIV    = 15
+	minCP    = 10
+	minHP    = 10
+)
+
+// লেভেল 1, 1.5, 2, ... 50 এর CP multiplier
+var cpMultipliers = []float64{
+	0.094, 0.13513743, 0.16639787, 0.19265091, 0.21573247, 0.23657266, 0.25572005, 0.27353038,
+	0.29024988, 0.30605738, 0.3210876, 0.33544503, 0.34921268, 0.36245775, 0.3752356, 0.38759241,
+	0.39956728, 0.41119355, 0.4225, 0.43292641, 0.44310755, 0.45305996, 0.4627984, 0.47233609,
+	0.48168495, 0.4908558, 0.49985844, 0.50870176, 0.51739395, 0.52594251, 0.5343543, 0.54263573,
+	0.5507927, 0.55883058, 0.5667545, 0.57456913, 0.5822789, 0.58988791, 0.5974, 0.60482366,
+	0.6121573, 0.61940412, 0.6265671, 0.63364914, 0.64065295, 0.64758096, 0.65443563, 0.66121925,
+	0.667934, 0.67458189, 0.6811649, 0.6876849, 0.69414365, 0.70054287, 0.7068842, 0.7131691,
+	0.7193991, 0.72557561, 0.7317, 0.73474101, 0.73776948, 0.74078557, 0.74378943, 0.74678121,
+	0.74976104, 0.7527291, 0.75568551, 0.75863036, 0.76138438, 0.76418652, 0.76698068, 0.76976728,
+	0.7725463, 0.77531753, 0.77808086, 0.78083638, 0.78358402, 0.78632373, 0.79030001, 0.79280001,
+	0.79530001, 0.79780001, 0.80030001, 0.80280001, 0.80530001, 0.80780001, 0.81030001, 0.81280001,
+	0.81530001, 0.81780001, 0.82030001, 0.82280001, 0.82530001, 0.82780001, 0.83030001, 0.83280001,
+	0.83530001, 0.83780001, 0.84030001,
+}
+
+type baseStats struct {
+	attack, defense, stamina int
+}
+
+type ivSet struct {
+	attack, defense, stamina int
+}
+
+// তিনটি base stat ই না থাকলে false
+func pokemonBaseStats(p *Pokemon) (baseStats, bool) {
+	if p.BaseAttack == nil || p.BaseDefense == nil || p.BaseStamina == nil {
+		return baseStats{}, false
+	}
+	return baseStats{*p.BaseAttack, *p.BaseDefense, *p.BaseStamina}, true
+}
+
+// লেভেল 1-50 এবং .0 বা .5 হলে CPM; সীমা আগে যাচাই হয়, যাতে Inf বা NaN int এ না যায়
+func cpMultiplier(level float64) (float64, bool) {
+	if !(level >= minLevel && level <= maxLevel) {
+		return 0, false
+	}
+	i := (level - minLevel) * 2
+	if i != math.Trunc(i) {
+		return 0, false
+	}
+	return cpMultipliers[int(i)], true
+}
+
+func levelAt(i int) float64 {
+	return minLevel + float64(i)/2
+}
+
+func computeCP(b baseStats, iv ivSet, cpm float64) int {
+	cp := float64(b.attack+iv.attack) * math.Sqrt(float64(b.defense+iv.defense)) * math.Sqrt(float64(b.stamina+iv.stamina)) * cpm * cpm / 10
+	return max(minCP, int(math.Floor(cp)))
+}
+
+func computeHP(b baseStats, iv ivSet, cpm float64) int {
+	return max(minHP, int(math.Floor(float64(b.stamina+iv.stamina)*cpm)))
+}
+
+func ivPercent(iv ivSet) float64 {
+	return math.Round(float64(iv.attack+iv.defense+iv.stamina)/(3*maxIV)*1000) / 10
+}
+
+// কোয়েরির একটি পূর্ণসংখ্যা, না থাকলে def; সীমার বাইরে হলে ত্রুটি
+func intParam(query url.Values, name string, def, lo, hi int) (int, *ValidationError) {
+	s := query.Get(name)
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < lo || n > hi {
+		return 0, &ValidationError{Field: name, Message: fmt.Sprintf("must be an integer between %d and %d", lo, hi)}
+	}
+	return n, nil
+}
+
+func levelParam(query url.Values, def float64) (float64, *ValidationError) {
+	s := query.Get("level")
+	if s == "" {
+		return def, nil
+	}
+	level, err := strconv.ParseFloat(s, 64)
+	if err == nil && !math.IsNaN(level) && !math.IsInf(level, 0) && level >= minLevel && level <= maxLevel {
+		if _, ok := cpMultiplier(level); ok {
+			return level, nil
+		}
+	}
+	return 0, &ValidationError{Field: "level", Message: fmt.Sprintf("must be between %g and %g in steps of 0.5", minLevel, maxLevel)}
+}
+
+// id দিয়ে রেকর্ড ও তার base stats; ত্রুটি হলে রেসপন্স পাঠিয়ে false (db লক ধরে রেখে)
+func statsSubject(w http.ResponseWriter, r *http.Request, errorTitle string) (*Pokemon, baseStats, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return nil, baseStats{}, false
+	}
+	for i := range db.pokemons {
+		p := &db.pokemons[i]
+		if p.ID != id {
+			continue
+		}
+		b, ok := pokemonBaseStats(p)
+		if !ok {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   errorTitle,
+				Details: []ValidationError{{Field: "base_stats", Message: fmt.Sprintf("%s has no base stats; set base_attack, base_defense and base_stamina", p.Name)}},
+			})
+			return nil, baseStats{}, false
+		}
+		return p, b, true
+	}
+	respondError(w, http.StatusNotFound, "Pokemon not found")
+	return nil, baseStats{}, false
+}
+
+// সরাসরি পরের ধাপগুলোর CP: multipliers দিয়ে আনুমানিক সীমা, base stats থাকলে একই লেভেল ও IV তে সঠিক মান
+func evolvedCPs(p *Pokemon, cp int, iv ivSet, cpm float64) []EvolvedCP {
+	evolved := []EvolvedCP{}
+	g := currentEvolutionGraph()
+	for _, num := range g.children[p.Num] {
+		e := EvolvedCP{Num: num}
+		n := g.nodes[num]
+		if n != nil {
+			e.Name = n.name
+		}
+		if len(p.Multipliers) > 0 {
+			lo, hi := p.Multipliers[0], p.Multipliers[0]
+			for _, m := range p.Multipliers[1:] {
+				lo, hi = min(lo, m), max(hi, m)
+			}
+			e.EstimatedMin = intPtr(int(math.Floor(float64(cp) * lo)))
+			e.EstimatedMax = intPtr(int(math.Floor(float64(cp) * hi)))
+		}
+		if n != nil && n.record != nil {
+			if b, ok := pokemonBaseStats(n.record); ok {
+				e.CP = intPtr(computeCP(b, iv, cpm))
+			}
+		}
+		evolved = append(evolved, e)
+	}
+	return evolved
+}
+
+// GET /api/pokemons/{id}/cp?level=20&attack=15&defense=15&stamina=15
+func getPokemonCP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	var errs []ValidationError
+	level, verr := levelParam(query, 20)
+	if verr != nil {
+		errs = append(errs, *verr)
+	}
+	var iv ivSet
+	for _, f := range []struct {
+		name   string
+		target *int
+	}{{"attack", &iv.attack}, {"defense", &iv.defense}, {"stamina", &iv.stamina}} {
+		n, verr := intParam(query, f.name, maxIV, 0, maxIV)
+		if verr != nil {
+			errs = append(errs, *verr)
+		}
+		*f.target = n
+	}
+	if len(errs) > 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid CP query", Details: errs})
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	p, b, ok := statsSubject(w, r, "Invalid CP query")
+	if !ok {
+		return
+	}
+
+	cpm, _ := cpMultiplier(level)
+	cp := computeCP(b, iv, cpm)
+	respondJSON(w, http.StatusOK, CPResponse{
+		ID:         p.ID,
+		Num:        p.Num,
+		Name:       p.Name,
+		Level:      level,
+		IV:         IVValues{Attack: iv.attack, Defense: iv.defense, Stamina: iv.stamina},
+		CP:         cp,
+		HP:         computeHP(b, iv, cpm),
+		Multiplier: cpm,
+		Evolutions: evolvedCPs(p, cp, iv, cpm),
+	})
+}
+
+// GET /api/pokemons/{id}/iv?cp=500&hp=60[&level=20]
+func getPokemonIVs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	var errs []ValidationError
+	for _, name := range []string{"cp", "hp"} {
+		if query.Get(name) == "" {
+			errs = append(errs, ValidationError{Field: name, Message: "is required"})
+		}
+	}
+	cp, verr := intParam(query, "cp", 0, minCP, math.MaxInt32)
+	if verr != nil && query.Get("cp") != "" {
+		errs = append(errs, *verr)
+	}
+	hp, verr := intParam(query, "hp", 0, minHP, math.MaxInt32)
+	if verr != nil && query.Get("hp") != "" {
+		errs = append(errs, *verr)
+	}
+	level, verr := levelParam(query, 0)
+	if verr != nil {
+		errs = append(errs, *verr)
+	}
+	if len(errs) > 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid IV query", Details: errs})
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	p, b, ok := statsSubject(w, r, "Invalid IV query")
+	if !ok {
+		return
+	}
+
+	matches := []IVMatch{}
+	for i := range cpMultipliers {
+		if level != 0 && levelAt(i) != level {
+			continue
+		}
+		cpm := cpMultipliers[i]
+		for s := 0; s <= maxIV; s++ {
+			if computeHP(b, ivSet{stamina: s}, cpm) != hp {
+				continue
+			}
+			for a := 0; a <= maxIV; a++ {
+				for d := 0; d <= maxIV; d++ {
+					iv := ivSet{a, d, s}
+					if computeCP(b, iv, cpm) == cp {
+						matches = append(matches, IVMatch{Level: levelAt(i), Attack: a, Defense: d, Stamina: s, Percent: ivPercent(iv)})
+					}
+				}
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Percent != matches[j].Percent {
+			return matches[i].Percent > matches[j].Percent
+		}
+		return matches[i].Level < matches[j].Level
+	})
+
+	response := IVResponse{ID: p.ID, Num: p.Num, Name: p.Name, CP: cp, HP: hp, Count: len(matches), Matches: matches}
+	if len(matches) > 0 {
+		response.MinPercent = &matches[len(matches)-1].Percent
+		response.MaxPercent = &matches[0].Percent
+	}
+	respondJSON(w, http.StatusOK, response)
+}
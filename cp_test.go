@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestComputeCPKnownValues(t *testing.T) {
+	bulbasaur := baseStats{118, 111, 128}
+	for _, tc := range []struct {
+		level  float64
+		iv     ivSet
+		cp, hp int
+	}{
+		{20, ivSet{15, 15, 15}, 637, 85},
+		{40, ivSet{15, 15, 15}, 1115, 113},
+		{1, ivSet{0, 0, 0}, 12, 12},
+	} {
+		cpm, ok := cpMultiplier(tc.level)
+		if !ok {
+			t.Fatalf("level %g: no multiplier", tc.level)
+		}
+		if cp := computeCP(bulbasaur, tc.iv, cpm); cp != tc.cp {
+			t.Errorf("level %g %+v: CP %d, want %d", tc.level, tc.iv, cp, tc.cp)
+		}
+		if hp := computeHP(bulbasaur, tc.iv, cpm); hp != tc.hp {
+			t.Errorf("level %g %+v: HP %d, want %d", tc.level, tc.iv, hp, tc.hp)
+		}
+	}
+
+	// খুব ছোট stats এও CP ও HP কমপক্ষে 10
+	if cp, hp := computeCP(baseStats{1, 1, 1}, ivSet{}, cpMultipliers[0]), computeHP(baseStats{1, 1, 1}, ivSet{}, cpMultipliers[0]); cp != minCP || hp != minHP {
+		t.Errorf("tiny stats: CP %d HP %d, want %d/%d", cp, hp, minCP, minHP)
+	}
+}
+
+func TestPokemonCPAndIVEndpoints(t *testing.T) {
+	mux, _ := newServeMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/1/cp?level=20", nil))
+	var cp CPResponse
+	json.Unmarshal(rec.Body.Bytes(), &cp)
+	if rec.Code != http.StatusOK || cp.CP != 637 || cp.HP != 85 {
+		t.Errorf("cp: status %d, CP %d HP %d, want 637/85", rec.Code, cp.CP, cp.HP)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/1/iv?cp=637&hp=85&level=20", nil))
+	var iv IVResponse
+	json.Unmarshal(rec.Body.Bytes(), &iv)
+	if rec.Code != http.StatusOK || iv.Count == 0 || iv.MaxPercent == nil || *iv.MaxPercent != 100 {
+		t.Errorf("iv: status %d, body %s, want a 100%% match", rec.Code, rec.Body)
+	}
+}
+
+func TestLevelRejectsNonFiniteAndOutOfRange(t *testing.T) {
+	mux, _ := newServeMux()
+
+	for _, level := range []string{"Inf", "-Inf", "+Inf", "NaN", "1e300", "-1e300", "0", "0.5", "50.5", "51", "20.3"} {
+		for _, path := range []string{"/api/pokemons/1/cp", "/api/pokemons/1/iv?cp=637&hp=85"} {
+			u, _ := url.Parse(path)
+			q := u.Query()
+			q.Set("level", level)
+			u.RawQuery = q.Encode()
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, u.String(), nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: status %d, want 400", u, rec.Code)
+			}
+		}
+		f, _ := strconv.ParseFloat(level, 64)
+		if _, ok := cpMultiplier(f); ok {
+			t.Errorf("cpMultiplier(%s) accepted", level)
+		}
+	}
+}
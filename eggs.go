@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==================== EGGS ====================
+//
+// egg এর মান একটি enum: "Not in Eggs" বা দূরত্ব অনুযায়ী "2 km", "5 km" ইত্যাদি।
+// লেখার সময় "2km", "2 KM", "2" এর মতো রূপ স্বাভাবিক রূপে বদলে যায়। হ্যাচের
+// আপেক্ষিক সম্ভাবনা প্রতিটি রেকর্ডের spawn ডেটা (spawn_chance বা avg_spawns) অনুপাতে।
+
+const (
+	notInEggs          = "Not in Eggs"
+	defaultHatchCount  = 1
+	maxHatchCount      = 100
+	defaultHatchWeight = "spawn_chance"
+)
+
+// পরিচিত ডিমের দূরত্ব, কিলোমিটারে
+var eggDistances = []int{2, 5, 7, 10, 12}
+
+var eggPattern = regexp.MustCompile(`^([0-9]+) ?(?:km)?$`)
+
+// "2 km" বা "Not in Eggs" এর তালিকা, স্কিমার enum
+func eggValues() []string {
+	values := []string{notInEggs}
+	for _, km := range eggDistances {
+		values = append(values, eggLabel(km))
+	}
+	return values
+}
+
+func eggLabel(km int) string {
+	return fmt.Sprintf("%d km", km)
+}
+
+// দূরত্ব (ডিমে না থাকলে 0); পরিচিত না হলে false
+func parseEgg(s string) (int, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == strings.ToLower(notInEggs) {
+		return 0, true
+	}
+	m := eggPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	km, _ := strconv.Atoi(m[1])
+	for _, d := range eggDistances {
+		if d == km {
+			return km, true
+		}
+	}
+	return 0, false
+}
+
+// পরিচিত রূপ হলে স্বাভাবিক লেবেলে বদলায়, না হলে যেমন আছে
+func canonicalEgg(s string) string {
+	km, ok := parseEgg(s)
+	switch {
+	case !ok:
+		return s
+	case km == 0 && strings.TrimSpace(s) == "":
+		return s
+	case km == 0:
+		return notInEggs
+	}
+	return eggLabel(km)
+}
+
+func normalizeEgg(p *Pokemon) {
+	p.Egg = canonicalEgg(p.Egg)
+}
+
+// স্কিমা যাচাইয়ের আগে বডির egg স্বাভাবিক করা, যাতে enum এ মেলে
+func normalizeRawEgg(value interface{}) {
+	if obj, ok := value.(map[string]interface{}); ok {
+		if s, ok := obj["egg"].(string); ok {
+			obj["egg"] = canonicalEgg(s)
+		}
+	}
+}
+
+// {distance} প্যারামিটার: "2", "2km" বা "2 km"
+func eggFromRequest(w http.ResponseWriter, r *http.Request) (int, bool) {
+	km, ok := parseEgg(r.PathValue("distance"))
+	if !ok || km == 0 {
+		respondError(w, http.StatusNotFound, "Unknown egg distance")
+		return 0, false
+	}
+	return km, true
+}
+
+func weightFromRequest(w http.ResponseWriter, r *http.Request, errorTitle string) (string, func(p *Pokemon) float64, bool) {
+	name := strings.TrimSpace(r.URL.Query().Get("weight"))
+	if name == "" {
+		name = defaultHatchWeight
+	}
+	weight, ok := spawnWeights[name]
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   errorTitle,
+			Details: []ValidationError{{Field: "weight", Message: fmt.Sprintf("unknown weight %q, expected spawn_chance or avg_spawns", name)}},
+		})
+		return "", nil, false
+	}
+	return name, weight, true
+}
+
+// km ডিম থেকে যেগুলো হ্যাচ হয়, সম্ভাবনা সহ; সব ওজন 0 হলে সমান ভাগ (db লক ধরে রেখে)
+func eggPool(km int, weight func(p *Pokemon) float64) []EggHatch {
+	pool := []EggHatch{}
+	total := 0.0
+	for i := range db.pokemons {
+		p := &db.pokemons[i]
+		if d, ok := parseEgg(p.Egg); !ok || d != km {
+			continue
+		}
+		wt := max(0, weight(p))
+		total += wt
+		pool = append(pool, EggHatch{ID: p.ID, Num: p.Num, Name: p.Name, Type: p.Type, Weight: wt})
+	}
+	for i := range pool {
+		if total > 0 {
+			pool[i].Probability = pool[i].Weight / total
+		} else {
+			pool[i].Probability = 1 / float64(len(pool))
+		}
+	}
+	sort.SliceStable(pool, func(i, j int) bool {
+		if pool[i].Probability != pool[j].Probability {
+			return pool[i].Probability > pool[j].Probability
+		}
+		return pool[i].ID < pool[j].ID
+	})
+	return pool
+}
+
+// GET /api/eggs
+func getEggs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	counts := make(map[int]int)
+	response := EggListResponse{Eggs: []EggSummary{}}
+	for _, p := range db.pokemons {
+		km, ok := parseEgg(p.Egg)
+		switch {
+		case !ok || strings.TrimSpace(p.Egg) == "":
+			response.Unknown++
+		case km == 0:
+			response.NotInEggs++
+		default:
+			counts[km]++
+		}
+	}
+	for _, km := range eggDistances {
+		response.Eggs = append(response.Eggs, EggSummary{Egg: eggLabel(km), Km: km, Count: counts[km]})
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GET /api/eggs/{distance}
+func getEggPool(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	km, ok := eggFromRequest(w, r)
+	if !ok {
+		return
+	}
+	weightBy, weight, ok := weightFromRequest(w, r, "Invalid egg query")
+	if !ok {
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	pool := eggPool(km, weight)
+	db.RUnlock()
+
+	for i := range pool {
+		pool[i].Probability = roundMetric(pool[i].Probability)
+	}
+	respondJSON(w, http.StatusOK, EggPoolResponse{Egg: eggLabel(km), Km: km, WeightBy: weightBy, Count: len(pool), Pokemons: pool})
+}
+
+// GET /api/eggs/{distance}/hatch?count=10&seed=42
+//
+// একই seed, count ও ডেটায় ফলাফল একই থাকে; seed না দিলে সময় থেকে নিয়ে রেসপন্সে ফেরত দেওয়া হয়।
+func hatchEgg(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	km, ok := eggFromRequest(w, r)
+	if !ok {
+		return
+	}
+	weightBy, weight, ok := weightFromRequest(w, r, "Invalid hatch query")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	var errs []ValidationError
+	count, verr := intParam(query, "count", defaultHatchCount, 1, maxHatchCount)
+	if verr != nil {
+		errs = append(errs, *verr)
+	}
+	seed := uint64(time.Now().UnixNano())
+	if s := query.Get("seed"); s != "" {
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			errs = append(errs, ValidationError{Field: "seed", Message: "must be a non-negative integer"})
+		}
+		seed = n
+	}
+	if len(errs) > 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid hatch query", Details: errs})
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	pool := eggPool(km, weight)
+	db.RUnlock()
+
+	if len(pool) == 0 {
+		respondError(w, http.StatusConflict, fmt.Sprintf("No Pokemon hatch from %s eggs", eggLabel(km)))
+		return
+	}
+
+	// পুলের ক্রম (সম্ভাবনা, তারপর ID) স্থির, তাই একই seed এ একই ফলাফল
+	rng := rand.New(rand.NewPCG(seed, seed))
+	hatches := make([]EggHatchResult, 0, count)
+	tallyIdx := make(map[int]int) // id -> tally এর সূচক
+	tally := []EggHatchTally{}
+	for range count {
+		x := rng.Float64()
+		pick := pool[len(pool)-1]
+		for _, h := range pool {
+			if x < h.Probability {
+				pick = h
+				break
+			}
+			x -= h.Probability
+		}
+		hatches = append(hatches, EggHatchResult{ID: pick.ID, Num: pick.Num, Name: pick.Name})
+		i, ok := tallyIdx[pick.ID]
+		if !ok {
+			i = len(tally)
+			tallyIdx[pick.ID] = i
+			tally = append(tally, EggHatchTally{ID: pick.ID, Num: pick.Num, Name: pick.Name})
+		}
+		tally[i].Count++
+	}
+	sort.SliceStable(tally, func(i, j int) bool {
+		if tally[i].Count != tally[j].Count {
+			return tally[i].Count > tally[j].Count
+		}
+		return tally[i].ID < tally[j].ID
+	})
+
+	respondJSON(w, http.StatusOK, EggHatchResponse{
+		Egg:      eggLabel(km),
+		Seed:     strconv.FormatUint(seed, 10),
+		WeightBy: weightBy,
+		Count:    count,
+		Hatches:  hatches,
+		Tally:    tally,
+	})
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func hatch(t *testing.T, mux *http.ServeMux, url string) EggHatchResponse {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d, body %s", url, rec.Code, rec.Body)
+	}
+	var body EggHatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestHatchSeedIsReproducible(t *testing.T) {
+	mux, _ := newServeMux()
+
+	first := hatch(t, mux, "/api/eggs/2/hatch?count=20&seed=42")
+	again := hatch(t, mux, "/api/eggs/2/hatch?count=20&seed=42")
+	if !reflect.DeepEqual(first, again) {
+		t.Errorf("same seed and count gave different results:\n%+v\n%+v", first, again)
+	}
+
+	other := hatch(t, mux, "/api/eggs/2/hatch?count=20&seed=43")
+	if reflect.DeepEqual(first.Hatches, other.Hatches) {
+		t.Errorf("seeds 42 and 43 gave the same hatches: %+v", first.Hatches)
+	}
+}
+
+// একই নামের দুটি রেকর্ড tally তে আলাদা থাকে
+func TestHatchTallyByID(t *testing.T) {
+	mux, _ := newServeMux()
+
+	db.Lock()
+	twin := Pokemon{ID: db.idCounter, Num: "901", Name: "Bulbasaur", Egg: "2 km", SpawnChance: 0.69}
+	db.idCounter++
+	db.pokemons = append(db.pokemons, twin)
+	indexPokemon(&twin)
+	db.Unlock()
+	defer func() {
+		db.Lock()
+		defer db.Unlock()
+		for i := range db.pokemons {
+			if db.pokemons[i].ID == twin.ID {
+				db.pokemons = append(db.pokemons[:i], db.pokemons[i+1:]...)
+				unindexPokemon(twin.ID)
+				break
+			}
+		}
+	}()
+
+	body := hatch(t, mux, "/api/eggs/2/hatch?count=100&seed=7")
+	ids := make(map[int]bool)
+	total := 0
+	for _, e := range body.Tally {
+		if e.Name == "Bulbasaur" {
+			ids[e.ID] = true
+		}
+		total += e.Count
+	}
+	if len(ids) != 2 {
+		t.Errorf("tally %+v: want separate entries for both Bulbasaur records", body.Tally)
+	}
+	if total != body.Count {
+		t.Errorf("tally adds up to %d, want %d", total, body.Count)
+	}
+}
+
+// egg ছাড়া তৈরি রেকর্ড "" হিসেবে ফেরত আসে, সেই বডি আবার PUT করা যায়
+func TestEmptyEggRoundTrip(t *testing.T) {
+	mux, _ := newServeMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/pokemons",
+		strings.NewReader(`{"num":"902","name":"Eggless","height":"1 m","weight":"1 kg","spawn_time":"N/A"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST: status %d, body %s", rec.Code, rec.Body)
+	}
+	var created PokemonResponse
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	url := fmt.Sprintf("/api/pokemons/%d", created.Pokemon.ID)
+	defer mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, url, nil))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, `"egg":""`) {
+		t.Fatalf("GET %s: body %s, want an empty egg", url, body)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, url, strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Errorf("PUT %s with its own GET body: status %d, body %s", url, rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ==================== EVOLUTION GRAPH ====================
+//
+// রেকর্ডের PrevEvolution/NextEvolution তালিকা পুরো চেইনের কপি (ট্রানজিটিভ), সরাসরি ধাপ নয়।
+// সেগুলো থেকে num কী ধরে প্রতিটি নোডের সরাসরি parent বের করা হয়, অগ্রাধিকার অনুযায়ী:
+//  1. রেকর্ডের নিজের PrevEvolution এর শেষ উপাদান
+//  2. অন্য রেকর্ডের PrevEvolution এর পরপর দুটি উপাদান
+//  3. যে রেকর্ডগুলো NextEvolution এ এটিকে রাখে তাদের মধ্যে সবচেয়ে গভীরটি
+// রেকর্ড নেই এমন num (শুধু স্টাবে আছে) missing নোড হিসেবে থাকে।
+
+type evolutionNode struct {
+	num    string
+	name   string
+	record *Pokemon // রেকর্ড না থাকলে nil
+}
+
+type evolutionGraph struct {
+	nodes    map[string]*evolutionNode
+	parent   map[string]string
+	children map[string][]string
+}
+
+// গ্রাফ প্রতিটি লেখার পর বাতিল হয় এবং পরের পড়ায় তৈরি হয়, তাই বাল্ক লেখায় বারবার বানাতে হয় না
+var (
+	evoGraphMu sync.Mutex
+	evoGraph   *evolutionGraph
+)
+
+func invalidateEvolutionGraph() {
+	evoGraphMu.Lock()
+	evoGraph = nil
+	evoGraphMu.Unlock()
+}
+
+// db লক (পড়া বা লেখা) ধরে রেখে কল করতে হবে
+func currentEvolutionGraph() *evolutionGraph {
+	evoGraphMu.Lock()
+	defer evoGraphMu.Unlock()
+	if evoGraph == nil {
+		evoGraph = buildEvolutionGraph(db.pokemons)
+	}
+	return evoGraph
+}
+
+func buildEvolutionGraph(pokemons []Pokemon) *evolutionGraph {
+	g := &evolutionGraph{
+		nodes:    make(map[string]*evolutionNode),
+		parent:   make(map[string]string),
+		children: make(map[string][]string),
+	}
+
+	for i := range pokemons {
+		p := pokemons[i]
+		g.nodes[p.Num] = &evolutionNode{num: p.Num, name: p.Name, record: &p}
+	}
+	for i := range pokemons {
+		for _, stubs := range [][]Evolution{pokemons[i].PrevEvolution, pokemons[i].NextEvolution} {
+			for _, stub := range stubs {
+				if g.nodes[stub.Num] == nil {
+					g.nodes[stub.Num] = &evolutionNode{num: stub.Num, name: stub.Name}
+				}
+			}
+		}
+	}
+
+	link := func(child, parent string) {
+		if _, ok := g.parent[child]; !ok && child != parent {
+			g.parent[child] = parent
+		}
+	}
+
+	for i := range pokemons {
+		if prev := pokemons[i].PrevEvolution; len(prev) > 0 {
+			link(pokemons[i].Num, prev[len(prev)-1].Num)
+		}
+	}
+	for i := range pokemons {
+		prev := pokemons[i].PrevEvolution
+		for j := 1; j < len(prev); j++ {
+			link(prev[j].Num, prev[j-1].Num)
+		}
+	}
+
+	// বাকিগুলোর জন্য NextEvolution থেকে অনুমান: সবচেয়ে গভীর রেকর্ডটিই সরাসরি parent
+	inferred := make(map[string]int) // num -> parent এর গভীরতা
+	for i := range pokemons {
+		p := &pokemons[i]
+		for _, stub := range p.NextEvolution {
+			if stub.Num == p.Num {
+				continue
+			}
+			d, ok := inferred[stub.Num]
+			if !ok {
+				if _, linked := g.parent[stub.Num]; linked {
+					continue
+				}
+			}
+			if !ok || len(p.PrevEvolution) > d {
+				inferred[stub.Num] = len(p.PrevEvolution)
+				g.parent[stub.Num] = p.Num
+			}
+		}
+	}
+
+	for child, parent := range g.parent {
+		g.children[parent] = append(g.children[parent], child)
+	}
+	for _, kids := range g.children {
+		sort.Slice(kids, func(i, j int) bool { return numLess(kids[i], kids[j]) })
+	}
+	return g
+}
+
+// Pokédex নম্বর সংখ্যা হিসেবে, না হলে স্ট্রিং হিসেবে
+func numLess(a, b string) bool {
+	an, aok := parseNumber(a)
+	bn, bok := parseNumber(b)
+	if aok && bok && an != bn {
+		return an < bn
+	}
+	return a < b
+}
+
+// parent ধরে উপরে গিয়ে ফ্যামিলির মূল; ভুল ডেটায় চক্র থাকলেও থামে
+func (g *evolutionGraph) root(num string) string {
+	seen := map[string]bool{num: true}
+	for {
+		parent, ok := g.parent[num]
+		if !ok || seen[parent] {
+			return num
+		}
+		seen[parent] = true
+		num = parent
+	}
+}
+
+// ফ্যামিলির ক্যান্ডি মূল রেকর্ড থেকে, মূলের রেকর্ড না থাকলে fallback
+func (g *evolutionGraph) candyFamily(root, fallback string) string {
+	if n := g.nodes[root]; n != nil && n.record != nil && n.record.Candy != "" {
+		return n.record.Candy
+	}
+	return fallback
+}
+
+// মূল থেকে num পর্যন্ত পথ, মূলসহ
+func (g *evolutionGraph) path(num string) []string {
+	path := []string{num}
+	seen := map[string]bool{num: true}
+	for {
+		parent, ok := g.parent[num]
+		if !ok || seen[parent] {
+			break
+		}
+		seen[parent] = true
+		path = append(path, parent)
+		num = parent
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// num থেকে নিচের পুরো ট্রি; current চিহ্নিত থাকে
+func (g *evolutionGraph) tree(num, current string) EvolutionChainNode {
+	return g.subtree(num, current, 0, intPtr(0), nil, make(map[string]bool))
+}
+
+func (g *evolutionGraph) subtree(num, current string, stage int, total, cost *int, seen map[string]bool) EvolutionChainNode {
+	seen[num] = true
+	n := g.nodes[num]
+
+	node := EvolutionChainNode{
+		Num:        num,
+		Stage:      stage,
+		CandyCost:  cost,
+		TotalCandy: total,
+		Current:    num == current,
+		EvolvesTo:  []EvolutionChainNode{},
+	}
+	if n != nil {
+		node.Name = n.name
+		if n.record != nil {
+			node.ID = &n.record.ID
+		} else {
+			node.Missing = true
+		}
+	}
+
+	// এই রেকর্ডের CandyCount = পরের ধাপে যেতে লাগে
+	var next *int
+	if n != nil && n.record != nil && n.record.CandyCount != nil {
+		next = n.record.CandyCount
+	}
+	var nextTotal *int
+	if total != nil && next != nil {
+		nextTotal = intPtr(*total + *next)
+	}
+
+	for _, child := range g.children[num] {
+		if seen[child] {
+			continue
+		}
+		node.EvolvesTo = append(node.EvolvesTo, g.subtree(child, current, stage+1, nextTotal, next, seen))
+	}
+	return node
+}
+
+func chainStats(node EvolutionChainNode) (stages int, branching bool) {
+	stages = 1
+	if len(node.EvolvesTo) > 1 {
+		branching = true
+	}
+	for _, child := range node.EvolvesTo {
+		s, b := chainStats(child)
+		stages = max(stages, s+1)
+		branching = branching || b
+	}
+	return stages, branching
+}
+
+// GET /api/pokemons/{id}/evolution-chain
+func getEvolutionChain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	for _, pokemon := range db.pokemons {
+		if pokemon.ID == id {
+			g := currentEvolutionGraph()
+			root := g.root(pokemon.Num)
+			chain := g.tree(root, pokemon.Num)
+			stages, branching := chainStats(chain)
+
+			respondJSON(w, http.StatusOK, EvolutionChainResponse{
+				Candy:     g.candyFamily(root, pokemon.Candy),
+				Stages:    stages,
+				Branching: branching,
+				Chain:     chain,
+			})
+			return
+		}
+	}
+
+	respondError(w, http.StatusNotFound, "Pokemon not found")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ?facets=type,weaknesses,egg - ফিল্টার করা (পেজ করার আগের) সেটের উপর প্রতি মানের গণনা
+
+type facetValues func(p *Pokemon) []string
+
+var facetFields = map[string]facetValues{
+	"type":       func(p *Pokemon) []string { return p.Type },
+	"weaknesses": func(p *Pokemon) []string { return p.Weaknesses },
+	"egg":        func(p *Pokemon) []string { return nonEmpty(p.Egg) },
+	"candy":      func(p *Pokemon) []string { return nonEmpty(p.Candy) },
+}
+
+func nonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return []string{s}
+}
+
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// facets প্যারামিটার যাচাই; ভুল হলে 400 পাঠিয়ে false ফেরত দেয়
+func facetsFromRequest(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	s := r.URL.Query().Get("facets")
+	if strings.TrimSpace(s) == "" {
+		return nil, true
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := facetFields[name]; !ok {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid facets",
+				Details: []ValidationError{{Field: "facets", Message: fmt.Sprintf("unknown facet %q, expected one of %s", name, facetNames())}},
+			})
+			return nil, false
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, true
+}
+
+func facetNames() string {
+	names := make(map[string]bool, len(facetFields))
+	for name := range facetFields {
+		names[name] = true
+	}
+	return sortedKeys(names)
+}
+
+// বাকেট বেশি গণনা আগে, সমান হলে মান অনুযায়ী; একটি রেকর্ডে একই মান দুবার থাকলেও একবার গোনা হয়
+func computeFacets(list []Pokemon, names []string) map[string][]FacetBucket {
+	facets := make(map[string][]FacetBucket, len(names))
+	for _, name := range names {
+		values := facetFields[name]
+		counts := make(map[string]int)
+		for i := range list {
+			seen := make(map[string]bool)
+			for _, v := range values(&list[i]) {
+				if !seen[v] {
+					seen[v] = true
+					counts[v]++
+				}
+			}
+		}
+
+		buckets := make([]FacetBucket, 0, len(counts))
+		for v, n := range counts {
+			buckets = append(buckets, FacetBucket{Value: v, Count: n})
+		}
+		sort.Slice(buckets, func(i, j int) bool {
+			if buckets[i].Count != buckets[j].Count {
+				return buckets[i].Count > buckets[j].Count
+			}
+			return buckets[i].Value < buckets[j].Value
+		})
+		facets[name] = buckets
+	}
+	return facets
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ?fields=id,name দিয়ে প্রজেকশন আর ?expand=next_evolution দিয়ে Evolution স্টাবের জায়গায় পুরো রেকর্ড
+type responseShape struct {
+	fields map[string]bool // nil মানে সব ফিল্ড
+	expand map[string]bool
+	// শুধু expand এর জন্য fields এ যোগ হওয়া ফিল্ড, এক্সপ্যান্ড করা রেকর্ডে প্রজেক্ট হয় না
+	expandOnly map[string]bool
+	byNum      map[string]Pokemon
+	// ?units=imperial
+	imperial bool
+	// প্রতিটি রেকর্ডে বাড়তি কী যোগ করে, যেমন সার্চের _score ও _highlights
+	annotate func(p *Pokemon) map[string]interface{}
+}
+
+var expandableFields = map[string]bool{
+	"next_evolution": true,
+	"prev_evolution": true,
+}
+
+// Pokemon এর JSON ফিল্ডের নাম
+var pokemonFieldNames = func() map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf(Pokemon{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}()
+
+// fields ও expand যাচাই; ভুল হলে 400 পাঠিয়ে false ফেরত দেয়
+func shapeFromRequest(w http.ResponseWriter, r *http.Request) (responseShape, bool) {
+	var shape responseShape
+	query := r.URL.Query()
+
+	invalid := func(field, message string) (responseShape, bool) {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid field selection",
+			Details: []ValidationError{{Field: field, Message: message}},
+		})
+		return shape, false
+	}
+
+	imperial, err := parseUnits(query.Get("units"))
+	if err != nil {
+		return invalid("units", err.Error())
+	}
+	shape.imperial = imperial
+
+	// ইম্পেরিয়ালে প্রজেকশন রূপান্তরের পরে হয়, তাই height_m/weight_kg এর জায়গায় height_in/weight_lb
+	if s := query.Get("fields"); s != "" {
+		shape.fields = make(map[string]bool)
+		for _, name := range strings.Split(s, ",") {
+			name = strings.TrimSpace(name)
+			if metric, ok := metricFieldFor(name); ok {
+				if !imperial {
+					return invalid("fields", fmt.Sprintf("field %q requires units=imperial, use %q", name, metric))
+				}
+				shape.fields[name] = true
+				continue
+			}
+			if !pokemonFieldNames[name] {
+				return invalid("fields", fmt.Sprintf("unknown field %q, expected one of %s", name, sortedKeys(pokemonFieldNames)))
+			}
+			if to, ok := imperialFields[name]; ok && imperial {
+				name = to
+			}
+			shape.fields[name] = true
+		}
+	}
+
+	if s := query.Get("expand"); s != "" {
+		shape.expand = make(map[string]bool)
+		for _, name := range strings.Split(s, ",") {
+			name = strings.TrimSpace(name)
+			if !expandableFields[name] {
+				return invalid("expand", fmt.Sprintf("cannot expand %q, expected one of %s", name, sortedKeys(expandableFields)))
+			}
+			shape.expand[name] = true
+			// এক্সপ্যান্ড করা ফিল্ড প্রজেকশনেও থাকবে
+			if shape.fields != nil && !shape.fields[name] {
+				shape.fields[name] = true
+				if shape.expandOnly == nil {
+					shape.expandOnly = make(map[string]bool)
+				}
+				shape.expandOnly[name] = true
+			}
+		}
+	}
+
+	return shape, true
+}
+
+func (s responseShape) active() bool {
+	return s.fields != nil || len(s.expand) > 0 || s.annotate != nil || s.imperial
+}
+
+// এক্সপ্যানশনের জন্য num অনুযায়ী রেকর্ড; db লক ধরে রেখে কল করতে হবে
+func (s *responseShape) resolve(pokemons []Pokemon) {
+	if len(s.expand) == 0 {
+		return
+	}
+	s.byNum = make(map[string]Pokemon, len(pokemons))
+	for _, p := range pokemons {
+		s.byNum[p.Num] = p
+	}
+}
+
+func (s responseShape) apply(p Pokemon) interface{} {
+	if !s.active() {
+		return p
+	}
+
+	record := toJSONMap(p)
+
+	// এক্সপ্যান্ড করা রেকর্ডও একই units ও fields পায়, তবে আবার এক্সপ্যান্ড বা annotate হয় না
+	nested := responseShape{fields: s.fields, imperial: s.imperial}
+	if len(s.expandOnly) > 0 {
+		nested.fields = make(map[string]bool, len(s.fields))
+		for name := range s.fields {
+			if !s.expandOnly[name] {
+				nested.fields[name] = true
+			}
+		}
+	}
+
+	for field := range s.expand {
+		var stubs []Evolution
+		if field == "next_evolution" {
+			stubs = p.NextEvolution
+		} else {
+			stubs = p.PrevEvolution
+		}
+		if len(stubs) == 0 {
+			continue
+		}
+
+		expanded := make([]interface{}, 0, len(stubs))
+		for _, stub := range stubs {
+			if full, ok := s.byNum[stub.Num]; ok {
+				expanded = append(expanded, nested.apply(full))
+			} else {
+				// রেফারেন্স করা রেকর্ড না থাকলে স্টাবই থাকে
+				expanded = append(expanded, stub)
+			}
+		}
+		record[field] = expanded
+	}
+
+	if s.imperial {
+		toImperial(record, &p)
+	}
+
+	if s.fields != nil {
+		for key := range record {
+			if !s.fields[key] {
+				delete(record, key)
+			}
+		}
+	}
+
+	if s.annotate != nil {
+		for k, v := range s.annotate(&p) {
+			record[k] = v
+		}
+	}
+
+	return record
+}
+
+// তালিকার এনভেলপ ঠিক রেখে শুধু data বদলায়
+func (s responseShape) applyList(response PokemonListResponse) interface{} {
+	if !s.active() {
+		return response
+	}
+
+	data := make([]interface{}, 0, len(response.Data))
+	for _, p := range response.Data {
+		data = append(data, s.apply(p))
+	}
+
+	envelope := toJSONMap(response)
+	envelope["data"] = data
+	return envelope
+}
+
+func toJSONMap(v interface{}) map[string]interface{} {
+	data, _ := json.Marshal(v)
+	var m map[string]interface{}
+	json.Unmarshal(data, &m)
+	return m
+}
+
+func sortedKeys(m map[string]bool) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ==================== FILTER QUERY LANGUAGE ====================
+//
+// GET /api/pokemons?filter=... এর জন্য ছোট একটা কোয়েরি ভাষা:
+//
+//	expr       := or
+//	or         := and ("OR" and)*
+//	and        := unary ("AND" unary)*
+//	unary      := "NOT" unary | "(" expr ")" | comparison
+//	comparison := field op value
+//	op         := ":" | "=" | "!=" | ">" | ">=" | "<" | "<="
+//	value      := word | "quoted string" | from..to
+//
+// উদাহরণ:
+//
+//	type:Fire AND spawn_chance>0.1
+//	(type:Grass OR type:Water) AND NOT has:prev_evolution
+//	type:Fire,Water AND weight:5..20
+//	egg:"2 km" AND spawn_time:22:00..02:00
+
+type filterExpr interface {
+	match(p *Pokemon) bool
+}
+
+type andExpr struct{ left, right filterExpr }
+type orExpr struct{ left, right filterExpr }
+type notExpr struct{ inner filterExpr }
+type predicate func(p *Pokemon) bool
+
+func (e andExpr) match(p *Pokemon) bool { return e.left.match(p) && e.right.match(p) }
+func (e orExpr) match(p *Pokemon) bool  { return e.left.match(p) || e.right.match(p) }
+func (e notExpr) match(p *Pokemon) bool { return !e.inner.match(p) }
+func (f predicate) match(p *Pokemon) bool {
+	return f(p)
+}
+
+// পার্স এরর, কোন টোকেনে সমস্যা তা দেখায়
+type FilterError struct {
+	Pos     int
+	Token   string
+	Message string
+}
+
+func (e *FilterError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s at position %d (end of filter)", e.Message, e.Pos+1)
+	}
+	return fmt.Sprintf("%s at position %d near %q", e.Message, e.Pos+1, e.Token)
+}
+
+type fieldKind int
+
+const (
+	listField fieldKind = iota
+	textField
+	numberField
+	timeField
+	presenceField
+)
+
+type filterField struct {
+	kind fieldKind
+	list func(p *Pokemon) []string
+	text func(p *Pokemon) string
+	num  func(p *Pokemon) (float64, bool)
+	// textField এ true হলে আংশিক মিল, না হলে পুরো মান মিলতে হবে
+	contains bool
+}
+
+var filterFields = map[string]filterField{
+	"type":         {kind: listField, list: func(p *Pokemon) []string { return p.Type }},
+	"weakness":     {kind: listField, list: func(p *Pokemon) []string { return p.Weaknesses }},
+	"weaknesses":   {kind: listField, list: func(p *Pokemon) []string { return p.Weaknesses }},
+	"egg":          {kind: textField, text: func(p *Pokemon) string { return p.Egg }},
+	"candy":        {kind: textField, text: func(p *Pokemon) string { return p.Candy }, contains: true},
+	"name":         {kind: textField, text: func(p *Pokemon) string { return p.Name }, contains: true},
+	"num":          {kind: numberField, num: func(p *Pokemon) (float64, bool) { return parseNumber(p.Num) }},
+	"spawn_chance": {kind: numberField, num: func(p *Pokemon) (float64, bool) { return p.SpawnChance, true }},
+	"avg_spawns":   {kind: numberField, num: func(p *Pokemon) (float64, bool) { return p.AvgSpawns, true }},
+	"candy_count": {kind: numberField, num: func(p *Pokemon) (float64, bool) {
+		if p.CandyCount == nil {
+			return 0, false
+		}
+		return float64(*p.CandyCount), true
+	}},
+	"base_attack":  {kind: numberField, num: func(p *Pokemon) (float64, bool) { return stat(p.BaseAttack) }},
+	"base_defense": {kind: numberField, num: func(p *Pokemon) (float64, bool) { return stat(p.BaseDefense) }},
+	"base_stamina": {kind: numberField, num: func(p *Pokemon) (float64, bool) { return stat(p.BaseStamina) }},
+	"height":       {kind: numberField, num: func(p *Pokemon) (float64, bool) { return measure(p.HeightM) }},
+	"weight":       {kind: numberField, num: func(p *Pokemon) (float64, bool) { return measure(p.WeightKg) }},
+	"spawn_time":   {kind: timeField, num: func(p *Pokemon) (float64, bool) { return parseClock(p.SpawnTime) }},
+	"has":          {kind: presenceField},
+}
+
+var presenceChecks = map[string]predicate{
+	"next_evolution": func(p *Pokemon) bool { return len(p.NextEvolution) > 0 },
+	"prev_evolution": func(p *Pokemon) bool { return len(p.PrevEvolution) > 0 },
+	"evolution":      func(p *Pokemon) bool { return len(p.NextEvolution) > 0 || len(p.PrevEvolution) > 0 },
+	"candy_count":    func(p *Pokemon) bool { return p.CandyCount != nil },
+	"multipliers":    func(p *Pokemon) bool { return len(p.Multipliers) > 0 },
+	"egg":            func(p *Pokemon) bool { return p.Egg != "" && !strings.EqualFold(p.Egg, "Not in Eggs") },
+}
+
+// ফিল্টার স্ট্রিং পার্স করে, খালি হলে nil ফেরত দেয়
+func parseFilter(input string) (filterExpr, error) {
+	p := &filterParser{input: input}
+	p.skipSpace()
+	if p.eof() {
+		return nil, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		tok, pos := p.peekToken()
+		return nil, &FilterError{Pos: pos, Token: tok, Message: "expected AND, OR or end of filter"}
+	}
+	return expr, nil
+}
+
+func matchesFilter(expr filterExpr, p *Pokemon) bool {
+	return expr == nil || expr.match(p)
+}
+
+type filterParser struct {
+	input string
+	pos   int
+}
+
+func (p *filterParser) eof() bool { return p.pos >= len(p.input) }
+
+func (p *filterParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// পরের টোকেন দেখে, কিন্তু পজিশন বদলায় না
+func (p *filterParser) peekToken() (string, int) {
+	start := p.pos
+	if p.eof() {
+		return "", start
+	}
+	if c := p.input[start]; c == '(' || c == ')' {
+		return string(c), start
+	}
+	end := start
+	for end < len(p.input) && !unicode.IsSpace(rune(p.input[end])) && p.input[end] != '(' && p.input[end] != ')' {
+		end++
+	}
+	return p.input[start:end], start
+}
+
+func (p *filterParser) acceptKeyword(kw string) bool {
+	p.skipSpace()
+	tok, _ := p.peekToken()
+	if strings.EqualFold(tok, kw) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	if p.acceptKeyword("NOT") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner}, nil
+	}
+
+	p.skipSpace()
+	if !p.eof() && p.input[p.pos] == '(' {
+		open := p.pos
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() || p.input[p.pos] != ')' {
+			tok, pos := p.peekToken()
+			if tok == "" {
+				return nil, &FilterError{Pos: open, Token: "(", Message: "unclosed parenthesis"}
+			}
+			return nil, &FilterError{Pos: pos, Token: tok, Message: "expected )"}
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterExpr, error) {
+	p.skipSpace()
+	fieldStart := p.pos
+	for !p.eof() && (isIdentChar(p.input[p.pos])) {
+		p.pos++
+	}
+	name := strings.ToLower(p.input[fieldStart:p.pos])
+	if name == "" {
+		tok, pos := p.peekToken()
+		return nil, &FilterError{Pos: pos, Token: tok, Message: "expected field name"}
+	}
+
+	field, ok := filterFields[name]
+	if !ok {
+		return nil, &FilterError{Pos: fieldStart, Token: p.input[fieldStart:p.pos], Message: "unknown field"}
+	}
+
+	opStart := p.pos
+	op := ""
+	for _, candidate := range []string{">=", "<=", "!=", ":", "=", ">", "<"} {
+		if strings.HasPrefix(p.input[p.pos:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		tok, pos := p.peekToken()
+		if tok == "" {
+			pos = opStart
+		}
+		return nil, &FilterError{Pos: pos, Token: tok, Message: fmt.Sprintf("expected operator after %q", name)}
+	}
+	p.pos += len(op)
+
+	valueStart := p.pos
+	value, err := p.readValue()
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		tok, _ := p.peekToken()
+		return nil, &FilterError{Pos: valueStart, Token: tok, Message: fmt.Sprintf("expected value after %s%s", name, op)}
+	}
+
+	fail := func(msg string) error {
+		return &FilterError{Pos: valueStart, Token: p.input[valueStart:p.pos], Message: msg}
+	}
+
+	switch field.kind {
+	case listField:
+		if op != ":" && op != "=" && op != "!=" {
+			return nil, fail(fmt.Sprintf("operator %s is not supported for %s", op, name))
+		}
+		wanted := strings.Split(value, ",")
+		pred := predicate(func(pk *Pokemon) bool {
+			for _, have := range field.list(pk) {
+				for _, w := range wanted {
+					if strings.EqualFold(have, strings.TrimSpace(w)) {
+						return true
+					}
+				}
+			}
+			return false
+		})
+		if op == "!=" {
+			return notExpr{pred}, nil
+		}
+		return pred, nil
+
+	case textField:
+		if op != ":" && op != "=" && op != "!=" {
+			return nil, fail(fmt.Sprintf("operator %s is not supported for %s", op, name))
+		}
+		wanted := normalizeText(value)
+		pred := predicate(func(pk *Pokemon) bool {
+			have := normalizeText(field.text(pk))
+			if field.contains && op == ":" {
+				return strings.Contains(have, wanted)
+			}
+			return have == wanted
+		})
+		if op == "!=" {
+			return notExpr{pred}, nil
+		}
+		return pred, nil
+
+	case numberField, timeField:
+		parse := parseNumber
+		if field.kind == timeField {
+			parse = parseClock
+		}
+
+		if from, to, isRange := strings.Cut(value, ".."); isRange && (op == ":" || op == "=") {
+			lo, hasLo := parse(from)
+			hi, hasHi := parse(to)
+			if (from != "" && !hasLo) || (to != "" && !hasHi) || (from == "" && to == "") {
+				return nil, fail(fmt.Sprintf("invalid range for %s", name))
+			}
+			return predicate(func(pk *Pokemon) bool {
+				v, ok := field.num(pk)
+				if !ok {
+					return false
+				}
+				// সময়ের রেঞ্জ মধ্যরাত পার হতে পারে, যেমন 22:00..02:00
+				if field.kind == timeField && hasLo && hasHi && lo > hi {
+					return v >= lo || v <= hi
+				}
+				return (!hasLo || v >= lo) && (!hasHi || v <= hi)
+			}), nil
+		}
+
+		want, ok := parse(value)
+		if !ok {
+			if field.kind == timeField {
+				return nil, fail(fmt.Sprintf("expected time (HH:MM) for %s", name))
+			}
+			return nil, fail(fmt.Sprintf("expected a finite number for %s", name))
+		}
+		return predicate(func(pk *Pokemon) bool {
+			v, ok := field.num(pk)
+			if !ok {
+				return false
+			}
+			return compareNumbers(v, op, want)
+		}), nil
+
+	case presenceField:
+		if op != ":" && op != "=" {
+			return nil, fail("has only supports the : operator")
+		}
+		check, ok := presenceChecks[strings.ToLower(value)]
+		if !ok {
+			return nil, fail("unknown field for has")
+		}
+		return check, nil
+	}
+
+	return nil, fail("unsupported field")
+}
+
+// কোটেড বা সাধারণ মান পড়ে
+func (p *filterParser) readValue() (string, error) {
+	if !p.eof() && p.input[p.pos] == '"' {
+		start := p.pos
+		p.pos++
+		var b strings.Builder
+		for !p.eof() {
+			c := p.input[p.pos]
+			if c == '\\' && p.pos+1 < len(p.input) {
+				b.WriteByte(p.input[p.pos+1])
+				p.pos += 2
+				continue
+			}
+			if c == '"' {
+				p.pos++
+				return b.String(), nil
+			}
+			b.WriteByte(c)
+			p.pos++
+		}
+		return "", &FilterError{Pos: start, Token: p.input[start:], Message: "unterminated quoted string"}
+	}
+
+	start := p.pos
+	for !p.eof() && !unicode.IsSpace(rune(p.input[p.pos])) && p.input[p.pos] != '(' && p.input[p.pos] != ')' {
+		p.pos++
+	}
+	return p.input[start:p.pos], nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func compareNumbers(v float64, op string, want float64) bool {
+	switch op {
+	case ">":
+		return v > want
+	case ">=":
+		return v >= want
+	case "<":
+		return v < want
+	case "<=":
+		return v <= want
+	case "!=":
+		return v != want
+	}
+	return v == want
+}
+
+// NaN ও Inf সংখ্যা হিসেবে গণ্য নয়: NaN এর সাথে প্রতিটি তুলনা false হয়ে ফিল্টার চুপচাপ [] ফেরত দিত
+func parseNumber(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// পার্স করা height_m/weight_kg; পার্স না হলে মান নেই
+func measure(v *float64) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+// base_attack/base_defense/base_stamina; না থাকলে মান নেই
+func stat(v *int) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return float64(*v), true
+}
+
+// "HH:MM" থেকে দিনের মিনিট
+func parseClock(s string) (float64, bool) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, false
+	}
+	hour, err1 := strconv.Atoi(h)
+	minute, err2 := strconv.Atoi(m)
+	if err1 != nil || err2 != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, false
+	}
+	return float64(hour*60 + minute), true
+}
+
+// কেস ও স্পেস উপেক্ষা করে তুলনা, যাতে egg:2km ও "2 km" মেলে
+func normalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), ""))
+}
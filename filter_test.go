@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func filterNames(t *testing.T, input string) []string {
+	t.Helper()
+	expr, err := parseFilter(input)
+	if err != nil {
+		t.Fatalf("parseFilter(%q): %v", input, err)
+	}
+
+	db.RLock()
+	defer db.RUnlock()
+	names := []string{}
+	for i := range db.pokemons {
+		if matchesFilter(expr, &db.pokemons[i]) {
+			names = append(names, db.pokemons[i].Name)
+		}
+	}
+	return names
+}
+
+func TestFilterPrecedenceAndParentheses(t *testing.T) {
+	for _, tc := range []struct {
+		filter string
+		want   []string
+	}{
+		// AND, OR এর চেয়ে শক্ত করে বাঁধে
+		{"type:Fire OR type:Grass AND spawn_chance>0.5", []string{"Bulbasaur", "Charmander", "Charmeleon", "Charizard"}},
+		{"(type:Fire OR type:Grass) AND spawn_chance>0.5", []string{"Bulbasaur"}},
+		{"type:Grass AND spawn_chance>0.5 OR type:Fire", []string{"Bulbasaur", "Charmander", "Charmeleon", "Charizard"}},
+		{"type:Grass AND (spawn_chance>0.5 OR type:Fire)", []string{"Bulbasaur"}},
+		// NOT শুধু পরের এককে প্রযোজ্য
+		{`NOT type:Fire AND egg:"2 km"`, []string{"Bulbasaur"}},
+		{`NOT (type:Fire AND egg:"2 km")`, []string{"Bulbasaur", "Ivysaur", "Venusaur", "Charmeleon", "Charizard"}},
+		{"NOT NOT type:Flying", []string{"Charizard"}},
+		{"((type:Flying))", []string{"Charizard"}},
+		{"type:fire and name:mander", []string{"Charmander"}},
+		{"num:2..3 OR (has:next_evolution AND type:Fire)", []string{"Ivysaur", "Venusaur", "Charmander", "Charmeleon"}},
+		{"spawn_time:22:00..02:00 AND NOT type:Grass", nil},
+		{"", []string{"Bulbasaur", "Ivysaur", "Venusaur", "Charmander", "Charmeleon", "Charizard"}},
+	} {
+		got := filterNames(t, tc.filter)
+		if tc.want == nil {
+			tc.want = []string{}
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("filter %q: got %v, want %v", tc.filter, got, tc.want)
+		}
+	}
+}
+
+func TestFilterErrorPositions(t *testing.T) {
+	for _, tc := range []struct {
+		filter  string
+		pos     int
+		token   string
+		message string
+	}{
+		{"spawn_chance>NaN", 13, "NaN", "finite number"},
+		{"spawn_chance>Inf", 13, "Inf", "finite number"},
+		{"type:Fire AND spawn_chance<=-Inf", 28, "-Inf", "finite number"},
+		{"avg_spawns=+Infinity", 11, "+Infinity", "finite number"},
+		{"spawn_chance>1e400", 13, "1e400", "finite number"},
+		{"spawn_chance:0..NaN", 13, "0..NaN", "invalid range"},
+		{"type:Fire AND (name:x OR spawn_chance>abc)", 38, "abc", "finite number"},
+		{"(type:Fire", 0, "(", "unclosed parenthesis"},
+		{"(type:Fire type:Grass)", 11, "type:Grass", "expected )"},
+		{"type:Fire )", 10, ")", "expected AND, OR or end of filter"},
+		{"type:Fire OR", 12, "", "expected field name"},
+		{"colour:red", 0, "colour", "unknown field"},
+		{"type~Fire", 4, "~Fire", "expected operator"},
+		{"type:Fire AND spawn_time:25:00", 25, "25:00", "expected time"},
+		{"name>char", 5, "char", "not supported"},
+	} {
+		_, err := parseFilter(tc.filter)
+		var fe *FilterError
+		if !errors.As(err, &fe) {
+			t.Errorf("filter %q: got %v, want a FilterError", tc.filter, err)
+			continue
+		}
+		if fe.Pos != tc.pos || fe.Token != tc.token || !strings.Contains(fe.Message, tc.message) {
+			t.Errorf("filter %q: got %q at %d (%q), want %q at %d (%q)",
+				tc.filter, fe.Token, fe.Pos, fe.Message, tc.token, tc.pos, tc.message)
+		}
+	}
+}
+
+func TestFilterRejectsNonFiniteOverHTTP(t *testing.T) {
+	mux, _ := newServeMux()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons?filter="+url.QueryEscape("spawn_chance>NaN"), nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400: %s", rec.Code, rec.Body)
+	}
+
+	var body ErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.Details) != 1 || body.Details[0].Field != "filter" ||
+		!strings.Contains(body.Details[0].Message, `position 14 near "NaN"`) {
+		t.Errorf("details = %+v", body.Details)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// বিল্ডের সময় -ldflags "-X main.version=v2.1.0" দিয়ে সেট করা যায়
+var version = ""
+
+// সার্ভারের অবস্থা, /healthz ও /readyz এর জন্য
+var (
+	startedAt        = time.Now()
+	dataLoaded       atomic.Bool
+	shuttingDown     atomic.Bool
+	lastPersistError sync.Map // store -> string, খালি মানে সর্বশেষ সেভ সফল
+)
+
+type BuildInfo struct {
+	Module     string `json:"module"`
+	Version    string `json:"version"`
+	Revision   string `json:"revision,omitempty"`
+	RevisionAt string `json:"revision_time,omitempty"`
+	Modified   bool   `json:"modified,omitempty"`
+	GoVersion  string `json:"go_version"`
+	StartedAt  string `json:"started_at"`
+	Uptime     string `json:"uptime"`
+	UptimeSecs int64  `json:"uptime_seconds"`
+}
+
+// debug.ReadBuildInfo থেকে ভার্সন ও VCS তথ্য
+func getBuildInfo() BuildInfo {
+	uptime := time.Since(startedAt)
+	info := BuildInfo{
+		Version:    version,
+		GoVersion:  runtime.Version(),
+		StartedAt:  startedAt.Format(time.RFC3339),
+		Uptime:     uptime.Round(time.Second).String(),
+		UptimeSecs: int64(uptime.Seconds()),
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		if info.Version == "" {
+			info.Version = "devel"
+		}
+		return info
+	}
+
+	info.Module = bi.Main.Path
+	if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	if info.Version == "" {
+		info.Version = "devel"
+	}
+
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.time":
+			info.RevisionAt = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+
+	return info
+}
+
+func recordPersistResult(store string, err error) {
+	if err != nil {
+		lastPersistError.Store(store, err.Error())
+		return
+	}
+	lastPersistError.Store(store, "")
+}
+
+// GET /healthz - প্রসেস চালু আছে কিনা
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// GET /readyz - ট্রাফিক নেওয়ার জন্য প্রস্তুত কিনা
+func readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	checks := map[string]string{
+		"data":        "ok",
+		"persistence": "ok",
+		"shutdown":    "ok",
+	}
+	ready := true
+
+	if !dataLoaded.Load() {
+		checks["data"] = "not loaded"
+		ready = false
+	}
+	for _, store := range persistStores {
+		if msg, _ := lastPersistError.Load(store); msg != nil && msg != "" {
+			checks["persistence"] = fmt.Sprintf("%s: %s", store, msg)
+			ready = false
+		}
+	}
+	if shuttingDown.Load() {
+		checks["shutdown"] = "draining"
+		ready = false
+	}
+
+	status := http.StatusOK
+	state := "ready"
+	if !ready {
+		status = http.StatusServiceUnavailable
+		state = "not ready"
+	}
+
+	respondJSON(w, status, ReadinessResponse{
+		Status: state,
+		Checks: checks,
+	})
+}
+
+// GET /version
+func versionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, getBuildInfo())
+}
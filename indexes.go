@@ -0,0 +1,37 @@
+package main
+
+// ==================== DERIVED INDEXES ====================
+//
+// db.pokemons থেকে তৈরি সব কাঠামো: সার্চ ইনডেক্স, সাজেস্ট ট্রি, স্পন সময় ও ইভোলিউশন গ্রাফ।
+// প্রতিটি লেখার পর হ্যান্ডলার db.Lock ধরে রেখে এগুলোর একটি কল করে।
+
+// রেকর্ড যোগ বা আপডেট
+func indexPokemon(p *Pokemon) {
+	searchIdx.remove(p.ID)
+	searchIdx.add(p)
+	suggestIdx.remove(p.ID)
+	suggestIdx.add(p)
+	spawnIdx.remove(p.ID)
+	spawnIdx.add(p)
+	invalidateEvolutionGraph()
+}
+
+func unindexPokemon(id int) {
+	searchIdx.remove(id)
+	suggestIdx.remove(id)
+	spawnIdx.remove(id)
+	invalidateEvolutionGraph()
+}
+
+// লোড বা সব মুছে ফেলার পর পুরোটা নতুন করে
+func rebuildIndexes() {
+	searchIdx = newSearchIndex()
+	suggestIdx = newSuggestTrie()
+	spawnIdx = newSpawnIndex()
+	for i := range db.pokemons {
+		searchIdx.add(&db.pokemons[i])
+		suggestIdx.add(&db.pokemons[i])
+		spawnIdx.add(&db.pokemons[i])
+	}
+	invalidateEvolutionGraph()
+}
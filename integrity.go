@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ==================== EVOLUTION INTEGRITY ====================
+//
+// next_evolution/prev_evolution এর প্রতিটি স্টাব num দিয়ে একটি বিদ্যমান রেকর্ডকে নির্দেশ করে
+// এবং নামও সেই রেকর্ডের সাথে মেলে। নাম বা num বদলালে অন্য রেকর্ডের স্টাবও বদলায়,
+// মুছে ফেলার সময় ?on_delete নীতি অনুযায়ী রেফারেন্স আটকায় বা সরিয়ে দেয়।
+// সব ফাংশন db.Lock ধরে রেখে কল করতে হবে।
+
+const (
+	deleteRestrict      = "restrict"       // অন্য রেকর্ড রেফারেন্স করলে 409
+	deleteCascadeUnlink = "cascade-unlink" // অন্য রেকর্ডের স্টাব থেকে সরিয়ে মুছে ফেলা (ডিফল্ট)
+)
+
+// num -> name, বর্তমান রেকর্ডগুলোর
+func knownNums() map[string]string {
+	nums := make(map[string]string, len(db.pokemons))
+	for _, p := range db.pokemons {
+		nums[p.Num] = p.Name
+	}
+	return nums
+}
+
+// p এর স্টাবগুলো known (ও একই বাল্ক ব্যাচের pending) রেকর্ডের সাথে মেলে কিনা।
+// stored হলো হালনাগাদের আগের রেকর্ড (তৈরির সময় nil); তার যে স্টাব অপরিবর্তিত থাকে তা যাচাই হয় না,
+// যাতে GET এর বডি আবার PUT করলে পুরনো ডেটার জন্য ব্যর্থ না হয়
+func checkEvolutionRefs(p, stored *Pokemon, known, pending map[string]string) []ValidationError {
+	var errs []ValidationError
+	var storedPrev, storedNext []Evolution
+	if stored != nil {
+		storedPrev, storedNext = stored.PrevEvolution, stored.NextEvolution
+	}
+	for _, list := range []struct {
+		field  string
+		stubs  []Evolution
+		stored []Evolution
+	}{{"prev_evolution", p.PrevEvolution, storedPrev}, {"next_evolution", p.NextEvolution, storedNext}} {
+		for i, stub := range list.stubs {
+			if slices.Contains(list.stored, stub) {
+				continue
+			}
+			path := fmt.Sprintf("%s[%d]", list.field, i)
+			if stub.Num == p.Num {
+				errs = append(errs, ValidationError{Field: path + ".num", Message: "cannot reference the Pokémon itself"})
+				continue
+			}
+
+			name, ok := known[stub.Num]
+			if !ok {
+				name, ok = pending[stub.Num]
+			}
+			if !ok {
+				errs = append(errs, ValidationError{Field: path + ".num", Message: fmt.Sprintf("no Pokémon with num %q", stub.Num)})
+				continue
+			}
+			if stub.Name != name {
+				errs = append(errs, ValidationError{Field: path + ".name", Message: fmt.Sprintf("is %q but Pokémon %s is %q", stub.Name, stub.Num, name)})
+			}
+		}
+	}
+	return errs
+}
+
+// num অন্য কোনো রেকর্ডে (skipID ছাড়া) আছে কিনা
+func numTaken(num string, skipID int) bool {
+	for _, p := range db.pokemons {
+		if p.Num == num && p.ID != skipID {
+			return true
+		}
+	}
+	return false
+}
+
+// old এর নাম বা num বদলালে অন্য রেকর্ডের স্টাব হালনাগাদ করে; কতগুলো রেকর্ড বদলেছে তা ফেরত দেয়
+func propagateIdentity(old, updated *Pokemon) int {
+	if old.Num == updated.Num && old.Name == updated.Name {
+		return 0
+	}
+	return rewriteEvolutionRefs(old.Num, updated.ID, func(stubs []Evolution) []Evolution {
+		for i := range stubs {
+			if stubs[i].Num == old.Num {
+				stubs[i] = Evolution{Num: updated.Num, Name: updated.Name}
+			}
+		}
+		return stubs
+	})
+}
+
+// num কে রেফারেন্স করা স্টাবগুলো সরিয়ে দেয়
+func unlinkEvolutionRefs(num string, skipID int) int {
+	return rewriteEvolutionRefs(num, skipID, func(stubs []Evolution) []Evolution {
+		kept := stubs[:0]
+		for _, stub := range stubs {
+			if stub.Num != num {
+				kept = append(kept, stub)
+			}
+		}
+		if len(kept) == 0 {
+			return nil
+		}
+		return kept
+	})
+}
+
+// num কে রেফারেন্স করা প্রতিটি রেকর্ডের (skipID ছাড়া) স্টাব তালিকায় rewrite চালায়
+func rewriteEvolutionRefs(num string, skipID int, rewrite func([]Evolution) []Evolution) int {
+	changed := 0
+	for i := range db.pokemons {
+		p := &db.pokemons[i]
+		if p.ID == skipID || !referencesNum(p, num) {
+			continue
+		}
+		// স্লাইস শেয়ার না করে কপির উপর বদলানো
+		p.PrevEvolution = rewrite(append([]Evolution(nil), p.PrevEvolution...))
+		p.NextEvolution = rewrite(append([]Evolution(nil), p.NextEvolution...))
+		p.UpdatedAt = time.Now()
+		indexPokemon(p)
+		changed++
+	}
+	return changed
+}
+
+func referencesNum(p *Pokemon, num string) bool {
+	for _, stubs := range [][]Evolution{p.PrevEvolution, p.NextEvolution} {
+		for _, stub := range stubs {
+			if stub.Num == num {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// p কে রেফারেন্স করা রেকর্ডগুলো, restrict এর ত্রুটিতে দেখানোর জন্য
+func evolutionReferrers(p *Pokemon) []ValidationError {
+	var refs []ValidationError
+	for i := range db.pokemons {
+		other := &db.pokemons[i]
+		if other.ID == p.ID {
+			continue
+		}
+		var fields []string
+		for _, list := range []struct {
+			field string
+			stubs []Evolution
+		}{{"prev_evolution", other.PrevEvolution}, {"next_evolution", other.NextEvolution}} {
+			for _, stub := range list.stubs {
+				if stub.Num == p.Num {
+					fields = append(fields, list.field)
+					break
+				}
+			}
+		}
+		if len(fields) > 0 {
+			refs = append(refs, ValidationError{
+				Field:   "on_delete",
+				Message: fmt.Sprintf("referenced by %s (id %d, num %s) in %s", other.Name, other.ID, other.Num, strings.Join(fields, " and ")),
+			})
+		}
+	}
+	return refs
+}
+
+// ?on_delete=restrict|cascade-unlink, ভুল হলে 400 পাঠিয়ে false ফেরত দেয়
+func deletePolicyFromRequest(w http.ResponseWriter, r *http.Request) (string, bool) {
+	switch policy := r.URL.Query().Get("on_delete"); policy {
+	case "":
+		return deleteCascadeUnlink, true
+	case deleteRestrict, deleteCascadeUnlink:
+		return policy, true
+	default:
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid delete policy",
+			Details: []ValidationError{{
+				Field:   "on_delete",
+				Message: fmt.Sprintf("unknown policy %q, expected %s or %s", policy, deleteRestrict, deleteCascadeUnlink),
+			}},
+		})
+		return "", false
+	}
+}
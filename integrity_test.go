@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// GET এর বডি অপরিবর্তিত PUT করলে সফল হতে হবে
+func TestPutRoundTripSeededRecords(t *testing.T) {
+	mux, _ := newServeMux()
+
+	db.RLock()
+	ids := pokemonIDs()
+	db.RUnlock()
+
+	for id := range ids {
+		url := fmt.Sprintf("/api/pokemons/%d", id)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", url, rec.Code)
+		}
+
+		body := rec.Body.Bytes()
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, url, bytes.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			t.Errorf("PUT %s with its own GET body: status %d, body %s", url, rec.Code, rec.Body)
+		}
+	}
+}
+
+// সংরক্ষিত ডেটায় আগে থেকে থাকা ভাঙা স্টাব PUT আটকায় না, নতুন ভাঙা স্টাব আটকায়
+func TestPutSkipsUnchangedDanglingRefs(t *testing.T) {
+	mux, _ := newServeMux()
+
+	db.Lock()
+	p := &db.pokemons[0]
+	id, original := p.ID, p.NextEvolution
+	p.NextEvolution = append(append([]Evolution(nil), original...), Evolution{Num: "999", Name: "Missingno"})
+	db.Unlock()
+	defer func() {
+		db.Lock()
+		defer db.Unlock()
+		for i := range db.pokemons {
+			if db.pokemons[i].ID == id {
+				db.pokemons[i].NextEvolution = original
+				indexPokemon(&db.pokemons[i])
+			}
+		}
+	}()
+
+	url := fmt.Sprintf("/api/pokemons/%d", id)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	body := rec.Body.String()
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, url, strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT with unchanged dangling ref: status %d, body %s", rec.Code, rec.Body)
+	}
+
+	body = strings.Replace(body, `"num":"999"`, `"num":"998"`, 1)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, url, strings.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("PUT with new dangling ref: status %d, want 400", rec.Code)
+	}
+}
+
+// API দিয়ে তৈরি রেকর্ড, যেগুলোর ঐচ্ছিক ফিল্ড খালি স্ট্রিং হিসেবে থাকে, GET এর বডি দিয়ে PUT করা যায়
+func TestPutRoundTripCreatedRecords(t *testing.T) {
+	mux, _ := newServeMux()
+
+	for _, payload := range []string{
+		`{"num":"903","name":"Minimal"}`,
+		`{"num":"904","name":"Sized","height":"2 ft 4 in","weight":"15.2 lb","spawn_time":"N/A","egg":"5km"}`,
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/pokemons", strings.NewReader(payload)))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("POST %s: status %d, body %s", payload, rec.Code, rec.Body)
+		}
+		var created PokemonResponse
+		json.Unmarshal(rec.Body.Bytes(), &created)
+		url := fmt.Sprintf("/api/pokemons/%d", created.Pokemon.ID)
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		body := rec.Body.String()
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, url, strings.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			t.Errorf("PUT %s with its own GET body %s: status %d, body %s", url, body, rec.Code, rec.Body)
+		}
+
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, url, nil))
+	}
+}
+
+func TestCreateRequiresNum(t *testing.T) {
+	mux, _ := newServeMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/pokemons", strings.NewReader(`{"name":"X"}`)))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"field":"num"`) {
+		t.Errorf("POST without num: status %d, body %s, want 400 on num", rec.Code, rec.Body)
+	}
+}
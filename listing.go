@@ -0,0 +1,169 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ==================== LISTING PIPELINE ====================
+//
+// সব কালেকশন এন্ডপয়েন্ট একই ধাপ অনুসরণ করে:
+// বেস শর্ত → type/search/filter → facets → sort → pagination → fields/expand → এনভেলপ
+
+type listQuery struct {
+	filters []filterExpr
+	sort    []sortKey
+	page    pageRequest
+	shape   responseShape
+	facets  []string
+}
+
+// কোয়েরি প্যারামিটার যাচাই; ভুল হলে 400 পাঠিয়ে false ফেরত দেয়
+func listQueryFromRequest(w http.ResponseWriter, r *http.Request) (listQuery, bool) {
+	var q listQuery
+	query := r.URL.Query()
+
+	sortKeys, ok := sortFromRequest(w, r)
+	if !ok {
+		return q, false
+	}
+	q.sort = sortKeys
+
+	pageReq, ok := pageFromRequest(w, r)
+	if !ok {
+		return q, false
+	}
+	q.page = pageReq
+
+	shape, ok := shapeFromRequest(w, r)
+	if !ok {
+		return q, false
+	}
+	q.shape = shape
+
+	facets, ok := facetsFromRequest(w, r)
+	if !ok {
+		return q, false
+	}
+	q.facets = facets
+
+	if typeFilter := query.Get("type"); typeFilter != "" {
+		q.filters = append(q.filters, hasType(typeFilter))
+	}
+
+	if search := query.Get("search"); search != "" {
+		q.filters = append(q.filters, predicate(func(p *Pokemon) bool {
+			return strings.Contains(strings.ToLower(p.Name), strings.ToLower(search)) ||
+				strings.Contains(p.Num, search)
+		}))
+	}
+
+	filter, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filter",
+			Details: []ValidationError{{Field: "filter", Message: err.Error()}},
+		})
+		return q, false
+	}
+	if filter != nil {
+		q.filters = append(q.filters, filter)
+	}
+
+	return q, true
+}
+
+// base শর্ত মেনে চলা রেকর্ডের উপর পুরো পাইপলাইন চালিয়ে রেসপন্স পাঠায়
+func listPokemons(w http.ResponseWriter, r *http.Request, base filterExpr) {
+	runListing(w, r, base, "")
+}
+
+// ফুল-টেক্সট সার্চ; sort না দিলে relevance অনুযায়ী সাজানো, প্রতিটি রেকর্ডে _score ও _highlights
+func listSearchResults(w http.ResponseWriter, r *http.Request, text string) {
+	runListing(w, r, nil, text)
+}
+
+func runListing(w http.ResponseWriter, r *http.Request, base filterExpr, text string) {
+	q, ok := listQueryFromRequest(w, r)
+	if !ok {
+		return
+	}
+	if base != nil {
+		q.filters = append([]filterExpr{base}, q.filters...)
+	}
+
+	db.rlockCtx(r.Context())
+	var hits map[int]*searchHit
+	if text != "" {
+		hits = searchIdx.search(text)
+		q.filters = append([]filterExpr{predicate(func(p *Pokemon) bool {
+			return hits[p.ID] != nil
+		})}, q.filters...)
+	}
+	result := selectPokemons(db.pokemons, q.filters)
+	q.shape.resolve(db.pokemons)
+	db.RUnlock()
+
+	if hits != nil {
+		if len(q.sort) == 0 {
+			q.sort = []sortKey{{field: "_score", desc: true, fn: func(p *Pokemon) (interface{}, bool) {
+				return hits[p.ID].score, true
+			}}}
+		}
+		q.shape.annotate = func(p *Pokemon) map[string]interface{} {
+			hit := hits[p.ID]
+			return map[string]interface{}{
+				"_score":      hit.score,
+				"_highlights": hit.highlights(p),
+			}
+		}
+	}
+
+	sortPokemons(result, q.sort)
+	response := paginate(w, r, result, q.sort, q.page)
+	if len(q.facets) > 0 {
+		response.Facets = computeFacets(result, q.facets)
+	}
+
+	respondJSON(w, http.StatusOK, q.shape.applyList(response))
+}
+
+// শর্ত মেলা রেকর্ডের কপি, কিছু না মিললেও খালি স্লাইস (null নয়)
+func selectPokemons(pokemons []Pokemon, filters []filterExpr) []Pokemon {
+	result := make([]Pokemon, 0)
+	for i := range pokemons {
+		matched := true
+		for _, f := range filters {
+			if !f.match(&pokemons[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, pokemons[i])
+		}
+	}
+	return result
+}
+
+func hasType(typeName string) predicate {
+	return func(p *Pokemon) bool {
+		for _, t := range p.Type {
+			if strings.EqualFold(t, typeName) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func hasWeakness(typeName string) predicate {
+	return func(p *Pokemon) bool {
+		for _, w := range p.Weaknesses {
+			if strings.EqualFold(w, typeName) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -1,17 +1,26 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
+const (
+	// শাটডাউন সিগন্যালের পর readiness false রেখে অপেক্ষা, যাতে লোড ব্যালান্সার ট্রাফিক সরিয়ে নেয়
+	shutdownDrainDelay = 5 * time.Second
+	shutdownTimeout    = 15 * time.Second
+)
+
 // Pokémon স্ট্রাকচার
 type Pokemon struct {
 	ID            int         `json:"id"`
@@ -21,6 +30,8 @@ type Pokemon struct {
 	Type          []string    `json:"type"`
 	Height        string      `json:"height"`
 	Weight        string      `json:"weight"`
+	HeightM       *float64    `json:"height_m,omitempty"`  // height থেকে পার্স করা, মিটারে
+	WeightKg      *float64    `json:"weight_kg,omitempty"` // weight থেকে পার্স করা, কিলোগ্রামে
 	Candy         string      `json:"candy"`
 	CandyCount    *int        `json:"candy_count,omitempty"`
 	Egg           string      `json:"egg"`
@@ -28,6 +39,9 @@ type Pokemon struct {
 	AvgSpawns     float64     `json:"avg_spawns"`
 	SpawnTime     string      `json:"spawn_time"`
 	Multipliers   []float64   `json:"multipliers"`
+	BaseAttack    *int        `json:"base_attack,omitempty"`
+	BaseDefense   *int        `json:"base_defense,omitempty"`
+	BaseStamina   *int        `json:"base_stamina,omitempty"`
 	Weaknesses    []string    `json:"weaknesses"`
 	NextEvolution []Evolution `json:"next_evolution,omitempty"`
 	PrevEvolution []Evolution `json:"prev_evolution,omitempty"`
@@ -43,18 +57,26 @@ type Evolution struct {
 // ইন-মেমরি ডাটাবেস
 type PokemonDB struct {
 	sync.RWMutex
-	pokemons  []Pokemon
-	idCounter int
+	pokemons    []Pokemon
+	idCounter   int
+	teams       []Team
+	teamCounter int
 }
 
 var db *PokemonDB
 
 func init() {
 	db = &PokemonDB{
-		pokemons:  make([]Pokemon, 0),
-		idCounter: 1,
+		pokemons:    make([]Pokemon, 0),
+		idCounter:   1,
+		teams:       make([]Team, 0),
+		teamCounter: 1,
 	}
 	loadInitialData()
+	if err := loadTeamsFromJSON(teamsFile); err != nil {
+		log.Printf("Warning: Could not load teams: %v", err)
+	}
+	dataLoaded.Store(true)
 }
 
 // প্রারম্ভিক ডেটা লোড
@@ -77,8 +99,11 @@ func loadInitialData() {
 		db.idCounter++
 		sampleData[i].CreatedAt = time.Now()
 		sampleData[i].UpdatedAt = time.Now()
+		normalizeMeasures(&sampleData[i])
+		normalizeEgg(&sampleData[i])
 		db.pokemons = append(db.pokemons, sampleData[i])
 	}
+	rebuildIndexes()
 
 	log.Printf("Loaded %d Pokémon\n", len(db.pokemons))
 }
@@ -103,23 +128,18 @@ func loadFromJSON(filename string) error {
 		db.idCounter++
 		pokemons[i].CreatedAt = time.Now()
 		pokemons[i].UpdatedAt = time.Now()
+		normalizeMeasures(&pokemons[i])
+		normalizeEgg(&pokemons[i])
 		db.pokemons = append(db.pokemons, pokemons[i])
 	}
+	rebuildIndexes()
 
 	return nil
 }
 
-// JSON ফাইলে সেভ
-func saveToJSON(filename string) error {
-	db.RLock()
-	defer db.RUnlock()
-
-	data, err := json.MarshalIndent(db.pokemons, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(filename, data, 0644)
+// JSON ফাইলে সেভ (কলারকে অবশ্যই db লক ধরে রাখতে হবে)
+func saveToJSONCtx(ctx context.Context, filename string) error {
+	return persistJSON(ctx, "pokemons", filename, db.pokemons)
 }
 
 // স্যাম্পল ডেটা
@@ -139,6 +159,9 @@ func getSampleData() []Pokemon {
 			AvgSpawns:     69,
 			SpawnTime:     "20:00",
 			Multipliers:   []float64{1.58},
+			BaseAttack:    intPtr(118),
+			BaseDefense:   intPtr(111),
+			BaseStamina:   intPtr(128),
 			Weaknesses:    []string{"Fire", "Ice", "Flying", "Psychic"},
 			NextEvolution: []Evolution{{Num: "002", Name: "Ivysaur"}, {Num: "003", Name: "Venusaur"}},
 		},
@@ -156,6 +179,9 @@ func getSampleData() []Pokemon {
 			AvgSpawns:     4.2,
 			SpawnTime:     "07:00",
 			Multipliers:   []float64{1.2, 1.6},
+			BaseAttack:    intPtr(151),
+			BaseDefense:   intPtr(143),
+			BaseStamina:   intPtr(155),
 			Weaknesses:    []string{"Fire", "Ice", "Flying", "Psychic"},
 			PrevEvolution: []Evolution{{Num: "001", Name: "Bulbasaur"}},
 			NextEvolution: []Evolution{{Num: "003", Name: "Venusaur"}},
@@ -172,6 +198,9 @@ func getSampleData() []Pokemon {
 			SpawnChance:   0.017,
 			AvgSpawns:     1.7,
 			SpawnTime:     "11:30",
+			BaseAttack:    intPtr(198),
+			BaseDefense:   intPtr(189),
+			BaseStamina:   intPtr(190),
 			Weaknesses:    []string{"Fire", "Ice", "Flying", "Psychic"},
 			PrevEvolution: []Evolution{{Num: "001", Name: "Bulbasaur"}, {Num: "002", Name: "Ivysaur"}},
 		},
@@ -189,6 +218,9 @@ func getSampleData() []Pokemon {
 			AvgSpawns:     25.3,
 			SpawnTime:     "08:45",
 			Multipliers:   []float64{1.65},
+			BaseAttack:    intPtr(116),
+			BaseDefense:   intPtr(93),
+			BaseStamina:   intPtr(118),
 			Weaknesses:    []string{"Water", "Ground", "Rock"},
 			NextEvolution: []Evolution{{Num: "005", Name: "Charmeleon"}, {Num: "006", Name: "Charizard"}},
 		},
@@ -206,10 +238,31 @@ func getSampleData() []Pokemon {
 			AvgSpawns:     1.2,
 			SpawnTime:     "19:00",
 			Multipliers:   []float64{1.79},
+			BaseAttack:    intPtr(158),
+			BaseDefense:   intPtr(126),
+			BaseStamina:   intPtr(151),
 			Weaknesses:    []string{"Water", "Ground", "Rock"},
 			PrevEvolution: []Evolution{{Num: "004", Name: "Charmander"}},
 			NextEvolution: []Evolution{{Num: "006", Name: "Charizard"}},
 		},
+		{
+			Num:           "006",
+			Name:          "Charizard",
+			Img:           "http://www.serebii.net/pokemongo/pokemon/006.png",
+			Type:          []string{"Fire", "Flying"},
+			Height:        "1.70 m",
+			Weight:        "90.5 kg",
+			Candy:         "Charmander Candy",
+			Egg:           "Not in Eggs",
+			SpawnChance:   0.0031,
+			AvgSpawns:     0.31,
+			SpawnTime:     "13:34",
+			BaseAttack:    intPtr(223),
+			BaseDefense:   intPtr(173),
+			BaseStamina:   intPtr(186),
+			Weaknesses:    []string{"Water", "Electric", "Rock"},
+			PrevEvolution: []Evolution{{Num: "004", Name: "Charmander"}, {Num: "005", Name: "Charmeleon"}},
+		},
 	}
 }
 
@@ -223,7 +276,7 @@ func enableCORS(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, traceparent, tracestate")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -242,7 +295,28 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, map[string]string{"error": message})
+	respondJSON(w, status, ErrorResponse{Error: message})
+}
+
+// বডি ডিকোড করে স্কিমা দিয়ে যাচাই, তারপর Pokemon এ রূপান্তর
+func decodePokemon(w http.ResponseWriter, r *http.Request, pokemon *Pokemon) bool {
+	var raw interface{}
+	if err := decodeJSON(r, &raw); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return false
+	}
+
+	if errs := validatePokemon(raw); len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return false
+	}
+
+	if err := convertJSON(raw, pokemon); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return false
+	}
+	normalizeMeasures(pokemon)
+	return true
 }
 
 // ==================== CRUD OPERATIONS ====================
@@ -255,18 +329,11 @@ func createPokemon(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var pokemon Pokemon
-	if err := json.NewDecoder(r.Body).Decode(&pokemon); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodePokemon(w, r, &pokemon) {
 		return
 	}
 
-	// Validation
-	if pokemon.Name == "" {
-		respondError(w, http.StatusBadRequest, "Name is required")
-		return
-	}
-
-	db.Lock()
+	db.lockCtx(r.Context())
 	defer db.Unlock()
 
 	// Check if Pokémon number already exists
@@ -277,6 +344,11 @@ func createPokemon(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if errs := checkEvolutionRefs(&pokemon, nil, knownNums(), nil); len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return
+	}
+
 	// Set ID and timestamps
 	pokemon.ID = db.idCounter
 	db.idCounter++
@@ -284,15 +356,16 @@ func createPokemon(w http.ResponseWriter, r *http.Request) {
 	pokemon.UpdatedAt = time.Now()
 
 	db.pokemons = append(db.pokemons, pokemon)
+	indexPokemon(&pokemon)
 
 	// Save to file
-	if err := saveToJSON("pokemon_backup.json"); err != nil {
+	if err := saveToJSONCtx(r.Context(), "pokemon_backup.json"); err != nil {
 		log.Printf("Warning: Could not save backup: %v", err)
 	}
 
-	respondJSON(w, http.StatusCreated, map[string]interface{}{
-		"message": "Pokemon created successfully",
-		"pokemon": pokemon,
+	respondJSON(w, http.StatusCreated, PokemonResponse{
+		Message: "Pokemon created successfully",
+		Pokemon: pokemon,
 	})
 }
 
@@ -303,79 +376,7 @@ func getAllPokemons(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Query parameters
-	query := r.URL.Query()
-	typeFilter := query.Get("type")
-	search := query.Get("search")
-	pageStr := query.Get("page")
-	limitStr := query.Get("limit")
-
-	db.RLock()
-	defer db.RUnlock()
-
-	// Filtering
-	filteredPokemons := make([]Pokemon, len(db.pokemons))
-	copy(filteredPokemons, db.pokemons)
-
-	if typeFilter != "" {
-		var result []Pokemon
-		for _, p := range filteredPokemons {
-			for _, t := range p.Type {
-				if strings.EqualFold(t, typeFilter) {
-					result = append(result, p)
-					break
-				}
-			}
-		}
-		filteredPokemons = result
-	}
-
-	if search != "" {
-		var result []Pokemon
-		for _, p := range filteredPokemons {
-			if strings.Contains(strings.ToLower(p.Name), strings.ToLower(search)) ||
-				strings.Contains(p.Num, search) {
-				result = append(result, p)
-			}
-		}
-		filteredPokemons = result
-	}
-
-	// Pagination
-	page := 1
-	limit := 20
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
-
-	start := (page - 1) * limit
-	end := start + limit
-
-	if start > len(filteredPokemons) {
-		start = len(filteredPokemons)
-	}
-	if end > len(filteredPokemons) {
-		end = len(filteredPokemons)
-	}
-
-	response := map[string]interface{}{
-		"total":       len(filteredPokemons),
-		"page":        page,
-		"limit":       limit,
-		"total_pages": (len(filteredPokemons) + limit - 1) / limit,
-		"data":        filteredPokemons[start:end],
-	}
-
-	respondJSON(w, http.StatusOK, response)
+	listPokemons(w, r, nil)
 }
 
 // 3. READ ONE - GET /api/pokemons/{id}
@@ -392,12 +393,18 @@ func getPokemonByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	db.RLock()
+	shape, ok := shapeFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	db.rlockCtx(r.Context())
 	defer db.RUnlock()
 
 	for _, pokemon := range db.pokemons {
 		if pokemon.ID == id {
-			respondJSON(w, http.StatusOK, pokemon)
+			shape.resolve(db.pokemons)
+			respondJSON(w, http.StatusOK, shape.apply(pokemon))
 			return
 		}
 	}
@@ -420,31 +427,48 @@ func updatePokemon(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var updatedPokemon Pokemon
-	if err := json.NewDecoder(r.Body).Decode(&updatedPokemon); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodePokemon(w, r, &updatedPokemon) {
 		return
 	}
 
-	db.Lock()
+	db.lockCtx(r.Context())
 	defer db.Unlock()
 
 	for i, pokemon := range db.pokemons {
 		if pokemon.ID == id {
+			if numTaken(updatedPokemon.Num, id) {
+				respondError(w, http.StatusConflict, "Pokemon with this number already exists")
+				return
+			}
+			known := knownNums()
+			delete(known, pokemon.Num)
+			if errs := checkEvolutionRefs(&updatedPokemon, &pokemon, known, nil); len(errs) > 0 {
+				respondValidationErrors(w, errs)
+				return
+			}
+
 			// Keep original ID and creation timestamp
 			updatedPokemon.ID = pokemon.ID
 			updatedPokemon.CreatedAt = pokemon.CreatedAt
 			updatedPokemon.UpdatedAt = time.Now()
 
 			db.pokemons[i] = updatedPokemon
+			indexPokemon(&updatedPokemon)
+			linked := propagateIdentity(&pokemon, &updatedPokemon)
 
 			// Save backup
-			if err := saveToJSON("pokemon_backup.json"); err != nil {
+			if err := saveToJSONCtx(r.Context(), "pokemon_backup.json"); err != nil {
 				log.Printf("Warning: Could not save backup: %v", err)
 			}
+			// টিম ফাইলে সদস্যরা num দিয়ে থাকে
+			if pokemon.Num != updatedPokemon.Num && inAnyTeam(id) {
+				saveTeams(r.Context())
+			}
 
-			respondJSON(w, http.StatusOK, map[string]interface{}{
-				"message": "Pokemon updated successfully",
-				"pokemon": updatedPokemon,
+			respondJSON(w, http.StatusOK, PokemonResponse{
+				Message:      "Pokemon updated successfully",
+				Pokemon:      updatedPokemon,
+				UpdatedLinks: linked,
 			})
 			return
 		}
@@ -468,12 +492,17 @@ func patchPokemon(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var updates map[string]interface{}
-	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+	if err := decodeJSON(r, &updates); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	db.Lock()
+	if errs := validatePokemonPatch(updates); len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	db.lockCtx(r.Context())
 	defer db.Unlock()
 
 	for i, pokemon := range db.pokemons {
@@ -494,6 +523,18 @@ func patchPokemon(w http.ResponseWriter, r *http.Request) {
 			updatedJSON, _ := json.Marshal(pokemonMap)
 			var updatedPokemon Pokemon
 			json.Unmarshal(updatedJSON, &updatedPokemon)
+			normalizeMeasures(&updatedPokemon)
+
+			if numTaken(updatedPokemon.Num, id) {
+				respondError(w, http.StatusConflict, "Pokemon with this number already exists")
+				return
+			}
+			known := knownNums()
+			delete(known, pokemon.Num)
+			if errs := checkEvolutionRefs(&updatedPokemon, &pokemon, known, nil); len(errs) > 0 {
+				respondValidationErrors(w, errs)
+				return
+			}
 
 			// Restore original ID and timestamps
 			updatedPokemon.ID = pokemon.ID
@@ -501,15 +542,22 @@ func patchPokemon(w http.ResponseWriter, r *http.Request) {
 			updatedPokemon.UpdatedAt = time.Now()
 
 			db.pokemons[i] = updatedPokemon
+			indexPokemon(&updatedPokemon)
+			linked := propagateIdentity(&pokemon, &updatedPokemon)
 
 			// Save backup
-			if err := saveToJSON("pokemon_backup.json"); err != nil {
+			if err := saveToJSONCtx(r.Context(), "pokemon_backup.json"); err != nil {
 				log.Printf("Warning: Could not save backup: %v", err)
 			}
+			// টিম ফাইলে সদস্যরা num দিয়ে থাকে
+			if pokemon.Num != updatedPokemon.Num && inAnyTeam(id) {
+				saveTeams(r.Context())
+			}
 
-			respondJSON(w, http.StatusOK, map[string]interface{}{
-				"message": "Pokemon updated successfully",
-				"pokemon": updatedPokemon,
+			respondJSON(w, http.StatusOK, PokemonResponse{
+				Message:      "Pokemon updated successfully",
+				Pokemon:      updatedPokemon,
+				UpdatedLinks: linked,
 			})
 			return
 		}
@@ -532,22 +580,43 @@ func deletePokemon(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	db.Lock()
+	policy, ok := deletePolicyFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	db.lockCtx(r.Context())
 	defer db.Unlock()
 
 	for i, pokemon := range db.pokemons {
 		if pokemon.ID == id {
+			if policy == deleteRestrict {
+				if refs := evolutionReferrers(&pokemon); len(refs) > 0 {
+					respondJSON(w, http.StatusConflict, ErrorResponse{
+						Error:   "Pokemon is referenced by other Pokemon's evolutions",
+						Details: refs,
+					})
+					return
+				}
+			}
+
 			// Remove the element
 			db.pokemons = append(db.pokemons[:i], db.pokemons[i+1:]...)
+			unindexPokemon(id)
+			unlinked := unlinkEvolutionRefs(pokemon.Num, id)
 
 			// Save backup
-			if err := saveToJSON("pokemon_backup.json"); err != nil {
+			if err := saveToJSONCtx(r.Context(), "pokemon_backup.json"); err != nil {
 				log.Printf("Warning: Could not save backup: %v", err)
 			}
+			if removeFromTeams(id) > 0 {
+				saveTeams(r.Context())
+			}
 
-			respondJSON(w, http.StatusOK, map[string]string{
-				"message": "Pokemon deleted successfully",
-				"id":      fmt.Sprintf("%d", id),
+			respondJSON(w, http.StatusOK, DeleteResponse{
+				Message:  "Pokemon deleted successfully",
+				ID:       fmt.Sprintf("%d", id),
+				Unlinked: unlinked,
 			})
 			return
 		}
@@ -563,34 +632,83 @@ func bulkCreatePokemons(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var newPokemons []Pokemon
-	if err := json.NewDecoder(r.Body).Decode(&newPokemons); err != nil {
+	var rawPokemons []interface{}
+	if err := decodeJSON(r, &rawPokemons); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	db.Lock()
+	db.lockCtx(r.Context())
 	defer db.Unlock()
 
 	var created []Pokemon
 	var errors []string
 
-	for _, pokemon := range newPokemons {
-		if pokemon.Name == "" {
-			errors = append(errors, "Pokemon name cannot be empty")
+	known := knownNums()
+	candidates := make(map[int]*Pokemon) // item index -> রেকর্ড
+	var order []int
+
+	for i, raw := range rawPokemons {
+		if errs := validatePokemon(raw); len(errs) > 0 {
+			for _, e := range errs {
+				errors = append(errors, fmt.Sprintf("item %d: %s", i, e))
+			}
 			continue
 		}
 
-		// Check if Pokémon number already exists
+		var pokemon Pokemon
+		if err := convertJSON(raw, &pokemon); err != nil {
+			errors = append(errors, fmt.Sprintf("item %d: %v", i, err))
+			continue
+		}
+		normalizeMeasures(&pokemon)
+
+		// Check if Pokémon number already exists (in the store or earlier in this batch)
 		exists := false
-		for _, p := range db.pokemons {
-			if p.Num == pokemon.Num {
-				errors = append(errors, fmt.Sprintf("Pokemon with number %s already exists", pokemon.Num))
+		if _, ok := known[pokemon.Num]; ok {
+			exists = true
+		}
+		for _, j := range order {
+			if candidates[j].Num == pokemon.Num {
 				exists = true
-				break
 			}
 		}
 		if exists {
+			errors = append(errors, fmt.Sprintf("Pokemon with number %s already exists", pokemon.Num))
+			continue
+		}
+
+		candidates[i] = &pokemon
+		order = append(order, i)
+	}
+
+	// ইভোলিউশন স্টাব একই ব্যাচের রেকর্ডকেও নির্দেশ করতে পারে; কোনো আইটেম বাদ পড়লে
+	// তার উপর নির্ভর করা আইটেমগুলোও আবার যাচাই হয়
+	for changed := true; changed; {
+		changed = false
+		pending := make(map[string]string, len(candidates))
+		for _, p := range candidates {
+			pending[p.Num] = p.Name
+		}
+		for _, i := range order {
+			p := candidates[i]
+			if p == nil {
+				continue
+			}
+			// বাল্ক শুধু নতুন num তৈরি করে, তুলনার মতো আগের রেকর্ড নেই
+			if errs := checkEvolutionRefs(p, nil, known, pending); len(errs) > 0 {
+				for _, e := range errs {
+					errors = append(errors, fmt.Sprintf("item %d: %s", i, e))
+				}
+				delete(candidates, i)
+				changed = true
+			}
+		}
+	}
+
+	for _, i := range order {
+		pokemon := candidates[i]
+		if pokemon == nil {
 			continue
 		}
 
@@ -600,21 +718,22 @@ func bulkCreatePokemons(w http.ResponseWriter, r *http.Request) {
 		pokemon.CreatedAt = time.Now()
 		pokemon.UpdatedAt = time.Now()
 
-		db.pokemons = append(db.pokemons, pokemon)
-		created = append(created, pokemon)
+		db.pokemons = append(db.pokemons, *pokemon)
+		indexPokemon(pokemon)
+		created = append(created, *pokemon)
 	}
 
 	// Save backup
-	if err := saveToJSON("pokemon_backup.json"); err != nil {
+	if err := saveToJSONCtx(r.Context(), "pokemon_backup.json"); err != nil {
 		log.Printf("Warning: Could not save backup: %v", err)
 	}
 
-	response := map[string]interface{}{
-		"message":          "Bulk create completed",
-		"created_count":    len(created),
-		"failed_count":     len(errors),
-		"created_pokemons": created,
-		"errors":           errors,
+	response := BulkCreateResponse{
+		Message:         "Bulk create completed",
+		CreatedCount:    len(created),
+		FailedCount:     len(errors),
+		CreatedPokemons: created,
+		Errors:          errors,
 	}
 
 	status := http.StatusCreated
@@ -640,21 +759,25 @@ func deleteAllPokemons(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	db.Lock()
+	db.lockCtx(r.Context())
 	defer db.Unlock()
 
 	count := len(db.pokemons)
 	db.pokemons = make([]Pokemon, 0)
 	db.idCounter = 1
+	rebuildIndexes()
 
 	// Save empty backup
-	if err := saveToJSON("pokemon_backup.json"); err != nil {
+	if err := saveToJSONCtx(r.Context(), "pokemon_backup.json"); err != nil {
 		log.Printf("Warning: Could not save backup: %v", err)
 	}
+	if clearTeamMembers() > 0 {
+		saveTeams(r.Context())
+	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"message":       "All Pokemon deleted successfully",
-		"deleted_count": count,
+	respondJSON(w, http.StatusOK, DeleteAllResponse{
+		Message:      "All Pokemon deleted successfully",
+		DeletedCount: count,
 	})
 }
 
@@ -671,20 +794,7 @@ func getPokemonsByType(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	db.RLock()
-	defer db.RUnlock()
-
-	var result []Pokemon
-	for _, pokemon := range db.pokemons {
-		for _, t := range pokemon.Type {
-			if strings.EqualFold(t, path) {
-				result = append(result, pokemon)
-				break
-			}
-		}
-	}
-
-	respondJSON(w, http.StatusOK, result)
+	listPokemons(w, r, hasType(path))
 }
 
 func getPokemonsWeakAgainst(w http.ResponseWriter, r *http.Request) {
@@ -699,64 +809,7 @@ func getPokemonsWeakAgainst(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	db.RLock()
-	defer db.RUnlock()
-
-	var result []Pokemon
-	for _, pokemon := range db.pokemons {
-		for _, w := range pokemon.Weaknesses {
-			if strings.EqualFold(w, path) {
-				result = append(result, pokemon)
-				break
-			}
-		}
-	}
-
-	respondJSON(w, http.StatusOK, result)
-}
-
-// 10. STATISTICS - GET /api/stats
-func getStats(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
-	db.RLock()
-	defer db.RUnlock()
-
-	// Calculate statistics
-	typeStats := make(map[string]int)
-	totalSpawnChance := 0.0
-	highestSpawn := db.pokemons[0]
-	lowestSpawn := db.pokemons[0]
-
-	for _, pokemon := range db.pokemons {
-		// Type statistics
-		for _, t := range pokemon.Type {
-			typeStats[t]++
-		}
-
-		// Spawn statistics
-		totalSpawnChance += pokemon.SpawnChance
-		if pokemon.SpawnChance > highestSpawn.SpawnChance {
-			highestSpawn = pokemon
-		}
-		if pokemon.SpawnChance < lowestSpawn.SpawnChance {
-			lowestSpawn = pokemon
-		}
-	}
-
-	avgSpawnChance := totalSpawnChance / float64(len(db.pokemons))
-
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"total_pokemons":   len(db.pokemons),
-		"by_type":          typeStats,
-		"avg_spawn_chance": avgSpawnChance,
-		"highest_spawn":    highestSpawn,
-		"lowest_spawn":     lowestSpawn,
-		"last_updated":     time.Now().Format(time.RFC3339),
-	})
+	listPokemons(w, r, hasWeakness(path))
 }
 
 // 11. SEARCH - GET /api/pokemons/search/{query}
@@ -772,21 +825,7 @@ func searchPokemons(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	db.RLock()
-	defer db.RUnlock()
-
-	var result []Pokemon
-	query := strings.ToLower(path)
-
-	for _, pokemon := range db.pokemons {
-		if strings.Contains(strings.ToLower(pokemon.Name), query) ||
-			strings.Contains(pokemon.Num, query) ||
-			strings.Contains(strings.ToLower(pokemon.Candy), query) {
-			result = append(result, pokemon)
-		}
-	}
-
-	respondJSON(w, http.StatusOK, result)
+	listSearchResults(w, r, path)
 }
 
 // 12. HOME HANDLER
@@ -796,146 +835,117 @@ func homeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := map[string]interface{}{
-		"message": "Pokémon REST API with Full CRUD Operations",
-		"version": "2.0.0",
-		"endpoints": map[string]string{
-			"GET    /":          "API Documentation",
-			"GET    /api/stats": "Get Pokémon statistics",
-
-			// CRUD Operations
-			"GET    /api/pokemons":      "Get all Pokémon (with pagination)",
-			"POST   /api/pokemons":      "Create new Pokémon",
-			"GET    /api/pokemons/{id}": "Get Pokémon by ID",
-			"PUT    /api/pokemons/{id}": "Update Pokémon (full)",
-			"PATCH  /api/pokemons/{id}": "Update Pokémon (partial)",
-			"DELETE /api/pokemons/{id}": "Delete Pokémon by ID",
-
-			// Bulk Operations
-			"POST   /api/pokemons/bulk":         "Bulk create Pokémon",
-			"DELETE /api/pokemons?confirm=true": "Delete all Pokémon",
-
-			// Special Queries
-			"GET    /api/pokemons/type/{type}":     "Get Pokémon by type",
-			"GET    /api/pokemons/weakness/{type}": "Get Pokémon weak against",
-			"GET    /api/pokemons/search/{query}":  "Search Pokémon",
-
-			// Query Parameters
-			"?type=Fire":       "Filter by type",
-			"?search=pika":     "Search by name/ID",
-			"?page=2&limit=10": "Pagination",
-		},
-		"example_payload": map[string]interface{}{
-			"name":         "Pikachu",
-			"num":          "025",
-			"type":         []string{"Electric"},
-			"height":       "0.41 m",
-			"weight":       "6.0 kg",
-			"candy":        "Pikachu Candy",
-			"candy_count":  50,
-			"egg":          "2 km",
-			"spawn_chance": 0.21,
-			"avg_spawns":   21,
-			"spawn_time":   "04:00",
-			"weaknesses":   []string{"Ground"},
-		},
+	response := HomeResponse{
+		Message:        "Pokémon REST API with Full CRUD Operations",
+		Version:        getBuildInfo().Version,
+		Docs:           "/docs",
+		OpenAPI:        "/openapi.json",
+		Endpoints:      make(map[string]string),
+		ExamplePayload: examplePayload(),
+	}
+
+	for _, rt := range apiRoutes() {
+		for _, op := range rt.Operations {
+			response.Endpoints[fmt.Sprintf("%-6s %s", op.Method, rt.Pattern)] = op.Summary
+		}
 	}
 
 	respondJSON(w, http.StatusOK, response)
 }
 
 // ==================== MAIN FUNCTION ====================
-func main() {
-	// রাউটিং সেটআপ
-	http.HandleFunc("/", enableCORS(homeHandler))
-
-	// Stats
-	http.HandleFunc("/api/stats", enableCORS(getStats))
-
-	// Main Pokémon endpoints
-	http.HandleFunc("/api/pokemons", enableCORS(func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			getAllPokemons(w, r)
-		case http.MethodPost:
-			createPokemon(w, r)
-		case http.MethodDelete:
-			deleteAllPokemons(w, r)
-		default:
-			respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		}
-	}))
-
-	// Bulk operations
-	http.HandleFunc("/api/pokemons/bulk", enableCORS(bulkCreatePokemons))
+// সব রাউট নিবন্ধিত মাক্স এবং নিবন্ধিত প্যাটার্নগুলো (টেস্টে ডকুমেন্টেশন মেলাতে)
+func newServeMux() (*http.ServeMux, []string) {
+	mux := http.NewServeMux()
+	var patterns []string
 
-	// Individual Pokémon operations
-	http.HandleFunc("/api/pokemons/", enableCORS(func(w http.ResponseWriter, r *http.Request) {
-		path := strings.TrimPrefix(r.URL.Path, "/api/pokemons/")
+	wrap := func(route string, handler http.HandlerFunc) http.HandlerFunc {
+		return instrument(route, traced(route, enableCORS(handler)))
+	}
+	handle := func(route string, handler http.HandlerFunc) {
+		mux.HandleFunc(route, wrap(route, handler))
+		patterns = append(patterns, route)
+	}
 
-		// Check for special endpoints first
-		if strings.HasPrefix(path, "type/") {
-			getPokemonsByType(w, r)
-			return
+	subresources := make(map[string]http.HandlerFunc)
+	for _, rt := range apiRoutes() {
+		if rt.Bare {
+			mux.HandleFunc(rt.Pattern, rt.handler())
+			patterns = append(patterns, rt.Pattern)
+			continue
 		}
-		if strings.HasPrefix(path, "weakness/") {
-			getPokemonsWeakAgainst(w, r)
-			return
+		if name, ok := pokemonSubresource(rt.Pattern); ok {
+			subresources[name] = wrap(rt.Pattern, rt.handler())
+			continue
 		}
-		if strings.HasPrefix(path, "search/") {
-			searchPokemons(w, r)
+		handle(rt.Pattern, rt.handler())
+	}
+	patterns = append(patterns, subresourcePattern)
+	mux.HandleFunc(subresourcePattern, func(w http.ResponseWriter, r *http.Request) {
+		if h, ok := subresources[r.PathValue("subresource")]; ok {
+			h(w, r)
 			return
 		}
+		http.NotFound(w, r)
+	})
 
-		// Check if it's just "/api/pokemons/"
-		if path == "" {
-			switch r.Method {
-			case http.MethodGet:
-				getAllPokemons(w, r)
-			case http.MethodPost:
-				createPokemon(w, r)
-			default:
-				respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
-			}
-			return
-		}
+	return mux, patterns
+}
 
-		// Regular ID-based operations
-		switch r.Method {
-		case http.MethodGet:
-			getPokemonByID(w, r)
-		case http.MethodPut:
-			updatePokemon(w, r)
-		case http.MethodPatch:
-			patchPokemon(w, r)
-		case http.MethodDelete:
-			deletePokemon(w, r)
-		default:
-			respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		}
-	}))
+func main() {
+	// রাউটিং সেটআপ
+	mux, patterns := newServeMux()
+	if err := checkRouteDocs(apiRoutes(), patterns); err != nil {
+		log.Fatalf("Route registry: %v", err)
+	}
 
 	// Start server
 	port := ":8080"
 
 	fmt.Println("=====================================")
 	fmt.Printf("📡 Server URL: http://localhost%s\n", port)
-	fmt.Println("📚 API Documentation: http://localhost" + port)
+	fmt.Println("📚 API Documentation: http://localhost" + port + "/docs")
 	fmt.Println("\n📋 Available Endpoints:")
-	fmt.Println("  GET    /                         - API Documentation")
-	fmt.Println("  GET    /api/stats                - Statistics")
-	fmt.Println("  GET    /api/pokemons             - Get all Pokémon")
-	fmt.Println("  POST   /api/pokemons             - Create new Pokémon")
-	fmt.Println("  GET    /api/pokemons/{id}        - Get Pokémon by ID")
-	fmt.Println("  PUT    /api/pokemons/{id}        - Update Pokémon (full)")
-	fmt.Println("  PATCH  /api/pokemons/{id}        - Update Pokémon (partial)")
-	fmt.Println("  DELETE /api/pokemons/{id}        - Delete Pokémon by ID")
-	fmt.Println("  POST   /api/pokemons/bulk        - Bulk create Pokémon")
-	fmt.Println("  DELETE /api/pokemons?confirm=true - Delete all Pokémon")
-	fmt.Println("  GET    /api/pokemons/type/{type} - Filter by type")
-	fmt.Println("  GET    /api/pokemons/search/{query} - Search Pokémon")
+	for _, rt := range apiRoutes() {
+		for _, op := range rt.Operations {
+			fmt.Printf("  %-6s %-34s - %s\n", op.Method, rt.Pattern, op.Summary)
+		}
+	}
 	fmt.Println("\n⚡ Example curl commands saved to curl_examples.txt")
 
 	// Start server
-	log.Fatal(http.ListenAndServe(port, nil))
+	shutdownTracing, err := setupTracing(context.Background())
+	if err != nil {
+		log.Fatalf("Could not set up tracing: %v", err)
+	}
+
+	server := &http.Server{Addr: port, Handler: mux}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+
+	// গ্রেসফুল শাটডাউন: আগে readiness false, তারপর ড্রেন
+	log.Println("Shutting down, draining connections...")
+	shuttingDown.Store(true)
+	time.Sleep(shutdownDrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Graceful shutdown failed: %v", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Printf("Could not flush traces: %v", err)
+	}
+	log.Println("Server stopped")
 }
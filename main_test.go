@@ -0,0 +1,65 @@
+package main
+
+import (
+	"log"
+	"os"
+	"slices"
+	"testing"
+)
+
+// হ্যান্ডলারগুলো বর্তমান ডিরেক্টরিতে ব্যাকআপ লেখে, তাই টেস্ট চলে একটি অস্থায়ী ডিরেক্টরিতে
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "pokemon-api-test")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		log.Fatal(err)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestRouteDocs(t *testing.T) {
+	_, patterns := newServeMux()
+	if err := checkRouteDocs(apiRoutes(), patterns); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRouteDocs(apiRoutes(), append(patterns, "/api/undocumented")); err == nil {
+		t.Error("unregistered pattern passed the route check")
+	}
+}
+
+// মাক্সে নিবন্ধিত প্রতিটি প্যাটার্ন ({$} সহ) স্পেকে থাকতে হবে; ডিসপ্যাচার থাকবে না,
+// তার সাবরিসোর্সগুলো থাকবে নিজ নিজ পাথে
+func TestRegisteredPatternsDocumented(t *testing.T) {
+	_, patterns := newServeMux()
+	paths := buildOpenAPI(apiRoutes())["paths"].(map[string]interface{})
+
+	for _, want := range []string{"/api/pokemons/{$}", subresourcePattern} {
+		if !slices.Contains(patterns, want) {
+			t.Errorf("pattern %s is not registered", want)
+		}
+	}
+	for _, pattern := range patterns {
+		if pattern == subresourcePattern {
+			continue
+		}
+		if _, ok := paths[specPath(pattern)]; !ok {
+			t.Errorf("pattern %s is registered but has no path %s in the OpenAPI spec", pattern, specPath(pattern))
+		}
+	}
+
+	if _, ok := paths[subresourcePattern]; ok {
+		t.Errorf("dispatcher %s is published in the OpenAPI spec", subresourcePattern)
+	}
+	for _, rt := range apiRoutes() {
+		if _, ok := pokemonSubresource(rt.Pattern); !ok {
+			continue
+		}
+		if _, ok := paths[rt.Pattern]; !ok {
+			t.Errorf("subresource %s has no path in the OpenAPI spec", rt.Pattern)
+		}
+	}
+}
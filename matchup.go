@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ==================== MATCHUP ====================
+//
+// দুটি রেকর্ডের টাইপ থেকে টাইপ চার্ট দিয়ে দুই দিকের গুণক। প্রতিটি আক্রমণ টাইপ
+// ধরা হয় নিজের টাইপের মুভ হিসেবে, তাই পক্ষের সেরা গুণকটিই তুলনায় আসে।
+
+const (
+	verdictAttacker = "attacker_favored"
+	verdictDefender = "defender_favored"
+	verdictEven     = "even"
+)
+
+// from এর প্রতিটি চেনা টাইপ to এর বিরুদ্ধে কত গুণ; সেরাটি চার্টের ক্রমে প্রথম সর্বোচ্চ
+func attackMultipliers(from, to *Pokemon) MatchupMultipliers {
+	m := MatchupMultipliers{ByType: make(map[string]float64), Best: 1}
+	first := true
+	for _, t := range pokemonTypes {
+		if !hasType(t)(from) {
+			continue
+		}
+		v := defenseMultiplier(t, to.Type)
+		m.ByType[t] = v
+		if first || v > m.Best {
+			m.Best, m.BestType = v, t
+			first = false
+		}
+	}
+	return m
+}
+
+func matchupSide(p *Pokemon) MatchupSide {
+	return MatchupSide{ID: p.ID, Num: p.Num, Name: p.Name, Type: p.Type}
+}
+
+// চেনা টাইপ না থাকলে ত্রুটি
+func knownTypes(field string, p *Pokemon) *ValidationError {
+	for _, t := range p.Type {
+		if _, ok := canonicalType(t); ok {
+			return nil
+		}
+	}
+	return &ValidationError{Field: field, Message: fmt.Sprintf("%s (id %d) has no known type", p.Name, p.ID)}
+}
+
+func formatMultiplier(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64) + "x"
+}
+
+func matchupSummary(a, d *Pokemon, offense, defense MatchupMultipliers) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s's best attack (%s) deals %s to %s; ", a.Name, offense.BestType, formatMultiplier(offense.Best), d.Name)
+	fmt.Fprintf(&b, "%s's best attack (%s) deals %s back.", d.Name, defense.BestType, formatMultiplier(defense.Best))
+	return b.String()
+}
+
+func matchupVerdict(offense, defense MatchupMultipliers) string {
+	switch {
+	case offense.Best > defense.Best:
+		return verdictAttacker
+	case offense.Best < defense.Best:
+		return verdictDefender
+	}
+	return verdictEven
+}
+
+// GET /api/matchup?attacker={id}&defender={id}
+func getMatchup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	var errs []ValidationError
+	ids := make(map[string]int, 2)
+	for _, field := range []string{"attacker", "defender"} {
+		raw := query.Get(field)
+		if raw == "" {
+			errs = append(errs, ValidationError{Field: field, Message: "is required"})
+			continue
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			errs = append(errs, ValidationError{Field: field, Message: fmt.Sprintf("invalid ID %q", raw)})
+			continue
+		}
+		ids[field] = id
+	}
+	if len(errs) > 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid matchup", Details: errs})
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	var attacker, defender *Pokemon
+	for i := range db.pokemons {
+		p := db.pokemons[i]
+		if p.ID == ids["attacker"] {
+			attacker = &p
+		}
+		if p.ID == ids["defender"] {
+			defender = &p
+		}
+	}
+	db.RUnlock()
+
+	if attacker == nil || defender == nil {
+		respondError(w, http.StatusNotFound, "Pokemon not found")
+		return
+	}
+
+	for _, check := range []*ValidationError{knownTypes("attacker", attacker), knownTypes("defender", defender)} {
+		if check != nil {
+			errs = append(errs, *check)
+		}
+	}
+	if len(errs) > 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid matchup", Details: errs})
+		return
+	}
+
+	offense := attackMultipliers(attacker, defender)
+	defense := attackMultipliers(defender, attacker)
+
+	respondJSON(w, http.StatusOK, MatchupResponse{
+		Attacker: matchupSide(attacker),
+		Defender: matchupSide(defender),
+		Offense:  offense,
+		Defense:  defense,
+		Verdict:  matchupVerdict(offense, defense),
+		Summary:  matchupSummary(attacker, defender, offense, defense),
+	})
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// persistJSON এর store লেবেলের মান
+var persistStores = []string{"pokemons", "teams"}
+
+// Prometheus মেট্রিক্স
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pokemon_api_http_requests_total",
+		Help: "Total HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pokemon_api_http_request_duration_seconds",
+		Help:    "HTTP request latency by route and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	httpRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "pokemon_api_http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served.",
+	})
+
+	persistDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pokemon_api_persist_duration_seconds",
+		Help:    "Time spent writing a JSON file by store.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"store"})
+
+	persistFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pokemon_api_persist_failures_total",
+		Help: "Number of failed JSON file writes by store.",
+	}, []string{"store"})
+
+	dbLockWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pokemon_api_db_lock_wait_seconds",
+		Help:    "Time spent waiting to acquire the PokemonDB lock.",
+		Buckets: []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+	}, []string{"mode"})
+
+	pokemonsByTypeDesc = prometheus.NewDesc(
+		"pokemon_api_pokemons",
+		"Number of stored Pokémon by type.",
+		[]string{"type"}, nil,
+	)
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		httpRequestsInFlight,
+		persistDuration,
+		persistFailuresTotal,
+		dbLockWait,
+		pokemonCollector{},
+	)
+
+	// প্রথম সেভের আগেও সিরিজগুলো দেখা যাবে
+	for _, store := range persistStores {
+		persistDuration.WithLabelValues(store)
+		persistFailuresTotal.WithLabelValues(store)
+	}
+}
+
+// স্ক্র্যাপের সময় ডাটাবেস থেকে টাইপ অনুযায়ী সংখ্যা গণনা
+type pokemonCollector struct{}
+
+func (pokemonCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pokemonsByTypeDesc
+}
+
+func (pokemonCollector) Collect(ch chan<- prometheus.Metric) {
+	db.RLock()
+	defer db.RUnlock()
+
+	counts := make(map[string]int)
+	for _, pokemon := range db.pokemons {
+		for _, t := range pokemon.Type {
+			counts[t]++
+		}
+	}
+
+	for t, n := range counts {
+		ch <- prometheus.MustNewConstMetric(pokemonsByTypeDesc, prometheus.GaugeValue, float64(n), t)
+	}
+}
+
+// রাউট অনুযায়ী রিকোয়েস্ট কাউন্ট, লেটেন্সি ও ইন-ফ্লাইট মাপার মিডলওয়্যার
+func instrument(route string, next http.HandlerFunc) http.HandlerFunc {
+	labels := prometheus.Labels{"route": route}
+
+	handler := promhttp.InstrumentHandlerInFlight(httpRequestsInFlight,
+		promhttp.InstrumentHandlerDuration(httpRequestDuration.MustCurryWith(labels),
+			promhttp.InstrumentHandlerCounter(httpRequestsTotal.MustCurryWith(labels), next),
+		),
+	)
+
+	return handler.ServeHTTP
+}
+
+// GET /metrics
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
+
+// লক পেতে কত সময় লাগছে তা রেকর্ড করে
+func (d *PokemonDB) Lock() {
+	start := time.Now()
+	d.RWMutex.Lock()
+	dbLockWait.WithLabelValues("write").Observe(time.Since(start).Seconds())
+}
+
+func (d *PokemonDB) RLock() {
+	start := time.Now()
+	d.RWMutex.RLock()
+	dbLockWait.WithLabelValues("read").Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsAfterInstrumentedRequest(t *testing.T) {
+	mux, _ := newServeMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/pokemons/1: status %d, body %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics: status %d", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	text := string(body)
+
+	for _, want := range []string{
+		`pokemon_api_http_requests_total{code="200",method="get",route="/api/pokemons/{id}"}`,
+		`pokemon_api_pokemons{type="Grass"}`,
+		`pokemon_api_persist_failures_total{store="pokemons"}`,
+		`pokemon_api_persist_failures_total{store="teams"}`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("metrics output is missing %s", want)
+		}
+	}
+}
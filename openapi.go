@@ -0,0 +1,330 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// স্পেক একবারই তৈরি হয়, রেজিস্ট্রি রানটাইমে বদলায় না
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  map[string]interface{}
+)
+
+func openAPISpec() map[string]interface{} {
+	openAPIOnce.Do(func() {
+		openAPIDoc = buildOpenAPI(apiRoutes())
+	})
+	return openAPIDoc
+}
+
+// GET /openapi.json
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, openAPISpec())
+}
+
+// GET /docs
+func docsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(docsPage)
+}
+
+// রেজিস্ট্রি ও Go টাইপ থেকে OpenAPI 3.1 ডকুমেন্ট তৈরি
+func buildOpenAPI(routes []Route) map[string]interface{} {
+	schemas := make(map[string]interface{})
+	paths := make(map[string]interface{})
+
+	errorSchema := schemaFor(reflect.TypeOf(ErrorResponse{}), schemas)
+
+	for _, rt := range routes {
+		item := make(map[string]interface{})
+
+		for _, op := range rt.Operations {
+			var params []interface{}
+			for _, p := range op.PathParams {
+				params = append(params, paramSpec(p, "path"))
+			}
+			for _, p := range op.Query {
+				params = append(params, paramSpec(p, "query"))
+			}
+
+			status := op.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			contentType := op.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+
+			success := map[string]interface{}{"description": http.StatusText(status)}
+			if op.Response != nil {
+				success["content"] = map[string]interface{}{
+					contentType: map[string]interface{}{
+						"schema": schemaFor(reflect.TypeOf(op.Response), schemas),
+					},
+				}
+			} else {
+				success["content"] = map[string]interface{}{
+					contentType: map[string]interface{}{
+						"schema": map[string]interface{}{"type": "string"},
+					},
+				}
+			}
+
+			responses := map[string]interface{}{
+				strconv.Itoa(status): success,
+			}
+			for _, code := range append(op.Errors, http.StatusMethodNotAllowed) {
+				responses[strconv.Itoa(code)] = map[string]interface{}{
+					"description": http.StatusText(code),
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{"schema": errorSchema},
+					},
+				}
+			}
+
+			spec := map[string]interface{}{
+				"operationId": operationID(op.Method, rt.Pattern),
+				"summary":     op.Summary,
+				"tags":        []string{rt.Tag},
+				"responses":   responses,
+			}
+			if op.Description != "" {
+				spec["description"] = op.Description
+			}
+			if len(params) > 0 {
+				spec["parameters"] = params
+			}
+			if op.RequestBody != nil {
+				media := map[string]interface{}{
+					"schema": schemaFor(reflect.TypeOf(op.RequestBody), schemas),
+				}
+				switch reflect.TypeOf(op.RequestBody).Kind() {
+				case reflect.Struct:
+					media["example"] = examplePayload()
+				case reflect.Slice:
+					media["example"] = []interface{}{examplePayload()}
+				case reflect.Map:
+					media["example"] = map[string]interface{}{"spawn_chance": 0.8, "height": "0.80 m"}
+				}
+				spec["requestBody"] = map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": media},
+				}
+			}
+
+			item[strings.ToLower(op.Method)] = spec
+		}
+
+		paths[specPath(rt.Pattern)] = item
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":       "Pokémon REST API",
+			"description": "Pokémon REST API with Full CRUD Operations",
+			"version":     getBuildInfo().Version,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+func paramSpec(p Param, in string) map[string]interface{} {
+	spec := map[string]interface{}{
+		"name":     p.Name,
+		"in":       in,
+		"required": p.Required || in == "path",
+		"schema":   map[string]interface{}{"type": p.Type},
+	}
+	if p.Description != "" {
+		spec["description"] = p.Description
+	}
+	if p.Example != nil {
+		spec["example"] = p.Example
+	}
+	return spec
+}
+
+// ServeMux এর "/api/pokemons/{$}" OpenAPI তে "/api/pokemons/"
+func specPath(pattern string) string {
+	return strings.TrimSuffix(pattern, "{$}")
+}
+
+// "GET /api/pokemons/{id}" -> "getApiPokemonsId", "/api/pokemons/{$}" -> "getApiPokemonsSlash"
+func operationID(method, pattern string) string {
+	pattern = strings.Replace(pattern, "{$}", "slash", 1)
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(pattern, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '.' || r == '-'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if pattern == "/" {
+		b.WriteString("Root")
+	}
+	return b.String()
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	pokemonType = reflect.TypeOf(Pokemon{})
+)
+
+// Go টাইপ থেকে JSON Schema, নামযুক্ত struct গুলো components এ যায়
+func schemaFor(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaFor(t.Elem(), schemas)
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Interface:
+		return map[string]interface{}{}
+	case reflect.Struct:
+		name := t.Name()
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+		if _, ok := schemas[name]; ok {
+			return ref
+		}
+
+		// Pokemon এর স্কিমা ভ্যালিডেশনের নিয়ম থেকেই আসে
+		if t == pokemonType {
+			schema := pokemonSchemaObject(func(name string) string { return "#/components/schemas/" + name })
+			schema["examples"] = []interface{}{exampleRecord()}
+			schemas[name] = schema
+			schemas["Evolution"] = evolutionSchemaObject()
+			return ref
+		}
+		schemas[name] = map[string]interface{}{} // রিকার্সিভ টাইপের জন্য আগে জায়গা রাখা
+
+		properties := make(map[string]interface{})
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			properties[name] = schemaFor(field.Type, schemas)
+
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+				// omitempty ছাড়া পয়েন্টার মানে মান না থাকলে null পাঠানো হয়
+				if field.Type.Kind() == reflect.Ptr {
+					properties[name] = nullable(properties[name].(map[string]interface{}))
+				}
+			}
+		}
+
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		schemas[name] = schema
+		return ref
+	}
+
+	return map[string]interface{}{}
+}
+
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if t, ok := schema["type"].(string); ok {
+		schema["type"] = []string{t, "null"}
+		return schema
+	}
+	return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+}
+
+// সার্ভার যেসব ফিল্ড নিজে সেট করে
+var readOnlyFields = map[string]bool{"id": true, "height_m": true, "weight_kg": true, "created_at": true, "updated_at": true}
+
+func examplePokemon() Pokemon {
+	p := Pokemon{
+		Num:         "025",
+		Name:        "Pikachu",
+		Img:         "http://www.serebii.net/pokemongo/pokemon/025.png",
+		Type:        []string{"Electric"},
+		Height:      "0.41 m",
+		Weight:      "6.0 kg",
+		Candy:       "Pikachu Candy",
+		CandyCount:  intPtr(50),
+		Egg:         "2 km",
+		SpawnChance: 0.21,
+		AvgSpawns:   21,
+		SpawnTime:   "04:00",
+		Multipliers: []float64{2.34},
+		Weaknesses:  []string{"Ground"},
+	}
+	normalizeMeasures(&p)
+	return p
+}
+
+// রিকোয়েস্ট বডির উদাহরণ (সার্ভার-নিয়ন্ত্রিত ফিল্ড ছাড়া)
+func examplePayload() map[string]interface{} {
+	data, _ := json.Marshal(examplePokemon())
+	var payload map[string]interface{}
+	json.Unmarshal(data, &payload)
+	for field := range readOnlyFields {
+		delete(payload, field)
+	}
+	return payload
+}
+
+// সম্পূর্ণ রেকর্ডের উদাহরণ, যেভাবে সার্ভার ফেরত দেয়
+func exampleRecord() Pokemon {
+	p := examplePokemon()
+	p.ID = 25
+	p.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.UpdatedAt = p.CreatedAt
+	return p
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// কার্সর সাইন করার সিক্রেট; CURSOR_SECRET না থাকলে প্রতি স্টার্টে নতুন তৈরি হয়,
+// তখন রিস্টার্টের পর পুরনো কার্সর অবৈধ হয়ে যায়
+var cursorSecret = func() []byte {
+	if s := os.Getenv("CURSOR_SECRET"); s != "" {
+		return []byte(s)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Could not generate cursor secret: %v", err)
+	}
+	return secret
+}()
+
+// কার্সরে থাকে শেষ/প্রথম রেকর্ডের সর্ট কী ও ID, আর কোন কোয়েরির জন্য তৈরি তার সিগনেচার
+type cursorPayload struct {
+	Query  string        `json:"q"`
+	Values []interface{} `json:"v"`
+	ID     int           `json:"id"`
+	Dir    string        `json:"d"` // "next" বা "prev"
+}
+
+type pageRequest struct {
+	limit      int
+	page       int
+	cursorMode bool
+	cursor     *cursorPayload
+	signature  string
+}
+
+// page, limit ও cursor যাচাই; ভুল হলে 400 পাঠিয়ে false ফেরত দেয়
+func pageFromRequest(w http.ResponseWriter, r *http.Request) (pageRequest, bool) {
+	query := r.URL.Query()
+	req := pageRequest{
+		limit:     defaultPageLimit,
+		page:      1,
+		signature: querySignature(r.URL.Path, query),
+	}
+
+	invalid := func(field, message string) (pageRequest, bool) {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid pagination",
+			Details: []ValidationError{{Field: field, Message: message}},
+		})
+		return req, false
+	}
+
+	if s := query.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return invalid("limit", fmt.Sprintf("must be an integer between 1 and %d", maxPageLimit))
+		}
+		req.limit = limit
+	}
+
+	if query.Has("cursor") {
+		if query.Has("page") {
+			return invalid("page", "cannot be combined with cursor")
+		}
+		req.cursorMode = true
+
+		if token := query.Get("cursor"); token != "" {
+			c, err := decodeCursor(token)
+			if err != nil {
+				return invalid("cursor", err.Error())
+			}
+			if c.Query != req.signature {
+				return invalid("cursor", "cursor was issued for a different filter or sort")
+			}
+			req.cursor = c
+		}
+		return req, true
+	}
+
+	if s := query.Get("page"); s != "" {
+		page, err := strconv.Atoi(s)
+		if err != nil || page < 1 {
+			return invalid("page", "must be a positive integer")
+		}
+		req.page = page
+	}
+
+	return req, true
+}
+
+// সর্ট করা পুরো তালিকা থেকে এক পেজ কেটে নেয় এবং Link হেডার সেট করে
+func paginate(w http.ResponseWriter, r *http.Request, list []Pokemon, keys []sortKey, req pageRequest) PokemonListResponse {
+	if req.cursorMode {
+		return paginateCursor(w, r, list, keys, req)
+	}
+
+	start := (req.page - 1) * req.limit
+	end := start + req.limit
+
+	if start > len(list) {
+		start = len(list)
+	}
+	if end > len(list) {
+		end = len(list)
+	}
+
+	totalPages := (len(list) + req.limit - 1) / req.limit
+	page := req.page
+
+	var links []string
+	if totalPages > 0 {
+		links = append(links, linkTo(r, "first", map[string]string{"page": "1"}))
+	}
+	if page > 1 && page <= totalPages {
+		links = append(links, linkTo(r, "prev", map[string]string{"page": strconv.Itoa(page - 1)}))
+	}
+	if page < totalPages {
+		links = append(links, linkTo(r, "next", map[string]string{"page": strconv.Itoa(page + 1)}))
+	}
+	if totalPages > 0 {
+		links = append(links, linkTo(r, "last", map[string]string{"page": strconv.Itoa(totalPages)}))
+	}
+	setLinkHeader(w, links)
+
+	return PokemonListResponse{
+		Total:      len(list),
+		Page:       &page,
+		Limit:      req.limit,
+		TotalPages: &totalPages,
+		Data:       list[start:end],
+	}
+}
+
+func paginateCursor(w http.ResponseWriter, r *http.Request, list []Pokemon, keys []sortKey, req pageRequest) PokemonListResponse {
+	start, end := 0, req.limit
+
+	if c := req.cursor; c != nil {
+		if c.Dir == "prev" {
+			// কার্সরের আগের রেকর্ডগুলো থেকে শেষ limit টি
+			end = sort.Search(len(list), func(i int) bool {
+				return compareToCursor(&list[i], keys, c) >= 0
+			})
+			start = end - req.limit
+		} else {
+			start = sort.Search(len(list), func(i int) bool {
+				return compareToCursor(&list[i], keys, c) > 0
+			})
+			end = start + req.limit
+		}
+	}
+
+	if start < 0 {
+		start = 0
+	}
+	if end > len(list) {
+		end = len(list)
+	}
+	if start > end {
+		start = end
+	}
+
+	page := list[start:end]
+	response := PokemonListResponse{
+		Total: len(list),
+		Limit: req.limit,
+		Data:  page,
+	}
+
+	var links []string
+	links = append(links, linkTo(r, "first", map[string]string{"cursor": ""}))
+	if start > 0 && len(page) > 0 {
+		response.PrevCursor = encodeCursor(newCursor(&page[0], keys, req.signature, "prev"))
+		links = append(links, linkTo(r, "prev", map[string]string{"cursor": response.PrevCursor}))
+	}
+	if end < len(list) && len(page) > 0 {
+		response.NextCursor = encodeCursor(newCursor(&page[len(page)-1], keys, req.signature, "next"))
+		links = append(links, linkTo(r, "next", map[string]string{"cursor": response.NextCursor}))
+	}
+	setLinkHeader(w, links)
+
+	return response
+}
+
+func newCursor(p *Pokemon, keys []sortKey, signature, dir string) cursorPayload {
+	c := cursorPayload{Query: signature, ID: p.ID, Dir: dir}
+	for _, key := range keys {
+		v, ok := key.value(p)
+		if !ok {
+			v = nil
+		}
+		c.Values = append(c.Values, v)
+	}
+	return c
+}
+
+// রেকর্ড কার্সরের আগে (<0), একই (0) নাকি পরে (>0)
+func compareToCursor(p *Pokemon, keys []sortKey, c *cursorPayload) int {
+	for i, key := range keys {
+		av, aok := key.value(p)
+		var bv interface{}
+		if i < len(c.Values) {
+			bv = c.Values[i]
+		}
+		if cmp := compareValues(av, aok, bv, bv != nil, key.desc); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case p.ID < c.ID:
+		return -1
+	case p.ID > c.ID:
+		return 1
+	}
+	return 0
+}
+
+// base64url(payload).base64url(hmac) - ক্লায়েন্টের কাছে অস্বচ্ছ, বদলালে ধরা পড়ে
+func encodeCursor(c cursorPayload) string {
+	data, _ := json.Marshal(c)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload))
+}
+
+func decodeCursor(token string) (*cursorPayload, error) {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, signCursor(payload)) {
+		return nil, fmt.Errorf("invalid or tampered cursor")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	var c cursorPayload
+	if err := json.Unmarshal(data, &c); err != nil || (c.Dir != "next" && c.Dir != "prev") {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	return &c, nil
+}
+
+func signCursor(payload string) []byte {
+	h := hmac.New(sha256.New, cursorSecret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)[:16]
+}
+
+// পাথ ও পেজিং ছাড়া বাকি কোয়েরি প্যারামিটারের হ্যাশ, যাতে কার্সর অন্য ফিল্টার/সর্টে ব্যবহার না হয়
+func querySignature(path string, query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		switch k {
+		case "cursor", "page", "limit", "fields", "expand", "facets", "units":
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s?", path)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s&", k, strings.Join(query[k], ","))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// বর্তমান URL এর কিছু প্যারামিটার বদলে RFC 8288 লিংক
+func linkTo(r *http.Request, rel string, set map[string]string) string {
+	query := r.URL.Query()
+	for k, v := range set {
+		query.Set(k, v)
+	}
+	u := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf("<%s>; rel=\"%s\"", u.String(), rel)
+}
+
+func setLinkHeader(w http.ResponseWriter, links []string) {
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type listPage struct {
+	status int
+	body   PokemonListResponse
+	errors ErrorResponse
+	links  map[string]string
+}
+
+func getList(t *testing.T, target string) listPage {
+	t.Helper()
+	mux, _ := newServeMux()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+	page := listPage{status: rec.Code, links: make(map[string]string)}
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &page.body); err != nil {
+			t.Fatalf("%s: %v", target, err)
+		}
+	} else {
+		json.Unmarshal(rec.Body.Bytes(), &page.errors)
+	}
+
+	// <url>; rel="next", <url>; rel="prev"
+	for _, link := range strings.Split(rec.Header().Get("Link"), ", ") {
+		u, rel, ok := strings.Cut(link, `>; rel="`)
+		if ok {
+			page.links[strings.TrimSuffix(rel, `"`)] = strings.TrimPrefix(u, "<")
+		}
+	}
+	return page
+}
+
+func pageNames(data []Pokemon) []string {
+	names := make([]string, len(data))
+	for i, p := range data {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func cursorURL(cursor, extra string) string {
+	return "/api/pokemons?limit=2&sort=-spawn_chance&cursor=" + url.QueryEscape(cursor) + extra
+}
+
+var bySpawnChance = []string{"Bulbasaur", "Charmander", "Ivysaur", "Venusaur", "Charmeleon", "Charizard"}
+
+func TestCursorWalkFollowsNextCursor(t *testing.T) {
+	var names []string
+	page := getList(t, cursorURL("", ""))
+	for pages := 1; ; pages++ {
+		if page.status != http.StatusOK {
+			t.Fatalf("page %d: status %d: %+v", pages, page.status, page.errors)
+		}
+		if page.body.Total != len(bySpawnChance) || page.body.Page != nil {
+			t.Errorf("page %d: total %d, page %v", pages, page.body.Total, page.body.Page)
+		}
+		names = append(names, pageNames(page.body.Data)...)
+
+		if page.body.NextCursor == "" {
+			if _, ok := page.links["next"]; ok {
+				t.Errorf("last page still has a next link")
+			}
+			break
+		}
+		if pages > len(bySpawnChance) {
+			t.Fatal("cursor walk does not terminate")
+		}
+		link, err := url.Parse(page.links["next"])
+		if err != nil || link.Query().Get("cursor") != page.body.NextCursor {
+			t.Errorf("page %d: next link %q does not carry next_cursor", pages, page.links["next"])
+		}
+		page = getList(t, cursorURL(page.body.NextCursor, ""))
+	}
+
+	if !slices.Equal(names, bySpawnChance) {
+		t.Errorf("walk returned %v, want %v", names, bySpawnChance)
+	}
+}
+
+func TestCursorWalkFollowsLinkHeader(t *testing.T) {
+	var forward [][]string
+	var last listPage
+	target := cursorURL("", "")
+	for target != "" {
+		page := getList(t, target)
+		if page.status != http.StatusOK {
+			t.Fatalf("%s: status %d: %+v", target, page.status, page.errors)
+		}
+		if first := page.links["first"]; !strings.Contains(first, "cursor=&") && !strings.HasSuffix(first, "cursor=") {
+			t.Errorf("first link %q does not reset the cursor", first)
+		}
+		forward = append(forward, pageNames(page.body.Data))
+		if len(forward) > len(bySpawnChance) {
+			t.Fatal("Link walk does not terminate")
+		}
+		target, last = page.links["next"], page
+	}
+	if got := slices.Concat(forward...); !slices.Equal(got, bySpawnChance) {
+		t.Errorf("forward walk returned %v, want %v", got, bySpawnChance)
+	}
+
+	// শেষ পেজ থেকে prev লিংক ধরে ফিরলে একই পেজগুলো উল্টো ক্রমে আসে
+	var backward [][]string
+	target = last.links["prev"]
+	for target != "" {
+		page := getList(t, target)
+		if page.status != http.StatusOK {
+			t.Fatalf("%s: status %d: %+v", target, page.status, page.errors)
+		}
+		backward = append(backward, pageNames(page.body.Data))
+		if len(backward) > len(bySpawnChance) {
+			t.Fatal("prev walk does not terminate")
+		}
+		target = page.links["prev"]
+	}
+	slices.Reverse(backward)
+	if want := forward[:len(forward)-1]; !slices.EqualFunc(backward, want, slices.Equal) {
+		t.Errorf("backward walk returned %v, want %v", backward, want)
+	}
+}
+
+func TestTamperedCursorRejected(t *testing.T) {
+	next := getList(t, cursorURL("", "")).body.NextCursor
+	payload, sig, _ := strings.Cut(next, ".")
+
+	// অন্য ID বসিয়ে পুরনো সিগনেচার দিয়ে পাঠানো
+	data, _ := base64.RawURLEncoding.DecodeString(payload)
+	var c cursorPayload
+	json.Unmarshal(data, &c)
+	c.ID = 999
+	forged, _ := json.Marshal(c)
+	forgedPayload := base64.RawURLEncoding.EncodeToString(forged)
+
+	flipped := []byte(sig)
+	flipped[0] ^= 1
+
+	for _, tc := range []struct {
+		name, cursor, message string
+	}{
+		{"forged payload", forgedPayload + "." + sig, "invalid or tampered cursor"},
+		{"flipped signature", payload + "." + string(flipped), "invalid or tampered cursor"},
+		{"missing signature", payload, "malformed cursor"},
+		{"garbage", "not-a-cursor", "malformed cursor"},
+	} {
+		page := getList(t, cursorURL(tc.cursor, ""))
+		if page.status != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", tc.name, page.status)
+			continue
+		}
+		if d := page.errors.Details; len(d) != 1 || d[0].Field != "cursor" || d[0].Message != tc.message {
+			t.Errorf("%s: details %+v, want cursor: %s", tc.name, d, tc.message)
+		}
+	}
+}
+
+func TestCursorBoundToFilterAndSort(t *testing.T) {
+	next := getList(t, cursorURL("", "")).body.NextCursor
+
+	for _, target := range []string{
+		"/api/pokemons?limit=2&sort=name&cursor=" + url.QueryEscape(next),
+		"/api/pokemons?limit=2&cursor=" + url.QueryEscape(next),
+		cursorURL(next, "&filter="+url.QueryEscape("type:Fire")),
+		cursorURL(next, "&type=Grass"),
+		"/api/pokemons/type/Fire?limit=2&sort=-spawn_chance&cursor=" + url.QueryEscape(next),
+	} {
+		page := getList(t, target)
+		if page.status != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, page.status)
+			continue
+		}
+		if d := page.errors.Details; len(d) != 1 || d[0].Field != "cursor" || !strings.Contains(d[0].Message, "different filter or sort") {
+			t.Errorf("%s: details %+v", target, d)
+		}
+	}
+
+	// limit ও fields বদলালে কার্সর বৈধ থাকে
+	page := getList(t, "/api/pokemons?limit=3&fields=id,name&sort=-spawn_chance&cursor="+url.QueryEscape(next))
+	if page.status != http.StatusOK {
+		t.Fatalf("status %d: %+v", page.status, page.errors)
+	}
+	if got := pageNames(page.body.Data); !slices.Equal(got, bySpawnChance[2:5]) {
+		t.Errorf("got %v, want %v", got, bySpawnChance[2:5])
+	}
+}
+
+func TestCursorAndPageCannotBeCombined(t *testing.T) {
+	page := getList(t, "/api/pokemons?cursor=&page=2")
+	if page.status != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", page.status)
+	}
+	if d := page.errors.Details; len(d) != 1 || d[0].Field != "page" {
+		t.Errorf("details %+v", d)
+	}
+}
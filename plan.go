@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ==================== EVOLUTION PLAN ====================
+//
+// লক্ষ্য রেকর্ড পর্যন্ত ফ্যামিলির মূল থেকে প্রতিটি ধাপের ক্যান্ডি (আগের ধাপের CandyCount)।
+// একাধিক লক্ষ্য হলে প্রতিটি আলাদা Pokémon থেকে শুরু হয়, তাই খরচ যোগ হয়;
+// ধাপগুলো লক্ষ্যের ক্রমে ধরে have থেকে খরচ বাদ দিয়ে remaining হিসাব হয়।
+
+// ?targets=3,6 -> ID তালিকা
+func parseTargetIDs(s string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// একটি লক্ষ্যের ধাপগুলো; spent হলো আগের লক্ষ্যগুলোর মোট খরচ (অজানা হলে nil)
+func planFor(g *evolutionGraph, target *Pokemon, have int, spent *int) (EvolutionPlan, *int) {
+	plan := EvolutionPlan{
+		Target: EvolutionPlanTarget{ID: target.ID, Num: target.Num, Name: target.Name},
+		Steps:  []EvolutionPlanStep{},
+		Total:  intPtr(0),
+	}
+
+	path := g.path(target.Num)
+	for i := 1; i < len(path); i++ {
+		from, to := g.nodes[path[i-1]], g.nodes[path[i]]
+		step := EvolutionPlanStep{FromNum: from.num, FromName: from.name, ToNum: to.num, ToName: to.name}
+		if from.record != nil && from.record.CandyCount != nil {
+			step.Candy = from.record.CandyCount
+		}
+
+		if step.Candy != nil && plan.Total != nil {
+			plan.Total = intPtr(*plan.Total + *step.Candy)
+		} else {
+			plan.Total = nil
+		}
+		if step.Candy != nil && spent != nil {
+			spent = intPtr(*spent + *step.Candy)
+			step.Remaining = intPtr(max(0, *spent-have))
+			step.Affordable = *step.Remaining == 0
+		} else {
+			spent = nil
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	return plan, spent
+}
+
+// GET /api/pokemons/{id}/evolution-plan?have=40&targets=3,6
+func getEvolutionPlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	query := r.URL.Query()
+	invalid := func(errs ...ValidationError) {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid evolution plan", Details: errs})
+	}
+	have, verr := intParam(query, "have", 0, 0, 1<<31-1)
+	if verr != nil {
+		verr.Message = "must be a non-negative integer"
+		invalid(*verr)
+		return
+	}
+	extra, err := parseTargetIDs(query.Get("targets"))
+	if err != nil {
+		invalid(ValidationError{Field: "targets", Message: err.Error()})
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	byID := make(map[int]*Pokemon, len(db.pokemons))
+	for i := range db.pokemons {
+		byID[db.pokemons[i].ID] = &db.pokemons[i]
+	}
+	primary := byID[id]
+	if primary == nil {
+		respondError(w, http.StatusNotFound, "Pokemon not found")
+		return
+	}
+
+	g := currentEvolutionGraph()
+	family := g.candyFamily(g.root(primary.Num), primary.Candy)
+
+	targets := []*Pokemon{primary}
+	var errs []ValidationError
+	for i, tid := range extra {
+		field := fmt.Sprintf("targets[%d]", i)
+		t := byID[tid]
+		if t == nil {
+			errs = append(errs, ValidationError{Field: field, Message: fmt.Sprintf("no Pokémon with id %d", tid)})
+			continue
+		}
+		if f := g.candyFamily(g.root(t.Num), t.Candy); f != family {
+			errs = append(errs, ValidationError{Field: field, Message: fmt.Sprintf("%s is in the %q family, not %q", t.Name, f, family)})
+			continue
+		}
+		targets = append(targets, t)
+	}
+	if len(errs) > 0 {
+		invalid(errs...)
+		return
+	}
+
+	response := EvolutionPlanResponse{Candy: family, Have: have, Plans: []EvolutionPlan{}}
+	spent := intPtr(0)
+	for _, t := range targets {
+		var plan EvolutionPlan
+		plan, spent = planFor(g, t, have, spent)
+		response.Plans = append(response.Plans, plan)
+	}
+	if spent != nil {
+		response.Total = spent
+		response.Needed = intPtr(max(0, *spent-have))
+		response.Affordable = *response.Needed == 0
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
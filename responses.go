@@ -0,0 +1,451 @@
+package main
+
+// রেসপন্স স্ট্রাকচার - হ্যান্ডলার ও OpenAPI স্পেক দুটোই এগুলো ব্যবহার করে
+
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details []ValidationError `json:"details,omitempty"`
+}
+
+// updated_links: নাম বা num বদলানোর ফলে যতগুলো রেকর্ডের ইভোলিউশন স্টাব হালনাগাদ হয়েছে
+type PokemonResponse struct {
+	Message      string  `json:"message"`
+	Pokemon      Pokemon `json:"pokemon"`
+	UpdatedLinks int     `json:"updated_links,omitempty"`
+}
+
+// অফসেট মোডে page/total_pages, কার্সর মোডে next_cursor/prev_cursor থাকে;
+// facets শুধু ?facets দিলে
+type PokemonListResponse struct {
+	Total      int                      `json:"total"`
+	Page       *int                     `json:"page,omitempty"`
+	Limit      int                      `json:"limit"`
+	TotalPages *int                     `json:"total_pages,omitempty"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+	PrevCursor string                   `json:"prev_cursor,omitempty"`
+	Facets     map[string][]FacetBucket `json:"facets,omitempty"`
+	Data       []Pokemon                `json:"data"`
+}
+
+// unlinked: যতগুলো রেকর্ডের ইভোলিউশন থেকে মুছে ফেলা রেকর্ডের স্টাব সরানো হয়েছে
+type DeleteResponse struct {
+	Message  string `json:"message"`
+	ID       string `json:"id"`
+	Unlinked int    `json:"unlinked,omitempty"`
+}
+
+type DeleteAllResponse struct {
+	Message      string `json:"message"`
+	DeletedCount int    `json:"deleted_count"`
+}
+
+type BulkCreateResponse struct {
+	Message         string    `json:"message"`
+	CreatedCount    int       `json:"created_count"`
+	FailedCount     int       `json:"failed_count"`
+	CreatedPokemons []Pokemon `json:"created_pokemons"`
+	Errors          []string  `json:"errors"`
+}
+
+// খালি ডেটাসেটে avg_spawn_chance null এবং highest/lowest_spawn খালি তালিকা;
+// সমান spawn_chance হলে সবগুলো রেকর্ড থাকে
+type StatsResponse struct {
+	TotalPokemons    int                     `json:"total_pokemons"`
+	ByType           map[string]int          `json:"by_type"`
+	ByTypePair       map[string]int          `json:"by_type_pair"`
+	ByEvolutionStage map[string]int          `json:"by_evolution_stage"`
+	AvgSpawnChance   *float64                `json:"avg_spawn_chance"`
+	HighestSpawn     []Pokemon               `json:"highest_spawn"`
+	LowestSpawn      []Pokemon               `json:"lowest_spawn"`
+	Distributions    map[string]Distribution `json:"distributions"` // spawn_chance, height (m), weight (kg)
+	LastUpdated      string                  `json:"last_updated"`
+}
+
+// মান না থাকলে count 0, বাকি সারাংশ null এবং histogram খালি
+type Distribution struct {
+	Count     int               `json:"count"`
+	Min       *float64          `json:"min"`
+	Max       *float64          `json:"max"`
+	Mean      *float64          `json:"mean"`
+	Median    *float64          `json:"median"`
+	P25       *float64          `json:"p25"`
+	P75       *float64          `json:"p75"`
+	StdDev    *float64          `json:"stddev"`
+	Histogram []HistogramBucket `json:"histogram"`
+}
+
+// [from, to) পরিসরের গণনা; শেষ বাকেটে to নিজেও থাকে
+type HistogramBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+// একটি গ্রুপ: কী এর মান (মান না থাকলে null), সদস্য সংখ্যা এবং অনুরোধ করা মেট্রিক
+type AggregateGroup struct {
+	Key     map[string]interface{} `json:"key"`
+	Count   int                    `json:"count"`
+	Metrics map[string]interface{} `json:"metrics"`
+}
+
+type AggregateResponse struct {
+	GroupBy []string         `json:"group_by"`
+	Metrics []string         `json:"metrics"`
+	Total   int              `json:"total"` // ফিল্টারের পর রেকর্ড সংখ্যা
+	Groups  []AggregateGroup `json:"groups"`
+}
+
+// ইভোলিউশন ট্রির একটি নোড; candy_cost আগের ধাপ থেকে এখানে আসতে লাগে (আগের রেকর্ডের candy_count),
+// total_candy মূল থেকে এখানে পর্যন্ত - কোনো ধাপের খরচ অজানা হলে null
+type EvolutionChainNode struct {
+	Num        string               `json:"num"`
+	Name       string               `json:"name"`
+	ID         *int                 `json:"id"` // রেকর্ড না থাকলে null
+	Stage      int                  `json:"stage"`
+	CandyCost  *int                 `json:"candy_cost"`
+	TotalCandy *int                 `json:"total_candy"`
+	Current    bool                 `json:"current,omitempty"`
+	Missing    bool                 `json:"missing,omitempty"`
+	EvolvesTo  []EvolutionChainNode `json:"evolves_to"`
+}
+
+type EvolutionChainResponse struct {
+	Candy     string             `json:"candy"`
+	Stages    int                `json:"stages"`
+	Branching bool               `json:"branching"`
+	Chain     EvolutionChainNode `json:"chain"`
+}
+
+// এই টাইপের আক্রমণ কোন টাইপের বিরুদ্ধে কেমন
+type TypeOffense struct {
+	SuperEffective   []string `json:"super_effective"`
+	NotVeryEffective []string `json:"not_very_effective"`
+	NoEffect         []string `json:"no_effect"`
+}
+
+// এই টাইপ(গুলো) কোন আক্রমণে কেমন ক্ষতি পায়; multipliers এ শুধু 1 নয় এমন গুণক
+type TypeDefense struct {
+	Weaknesses  []string           `json:"weaknesses"`
+	Resistances []string           `json:"resistances"`
+	Immunities  []string           `json:"immunities"`
+	Multipliers map[string]float64 `json:"multipliers"`
+}
+
+type TypeInfo struct {
+	Name         string      `json:"name"`
+	Offense      TypeOffense `json:"offense"`
+	Defense      TypeDefense `json:"defense"`
+	PokemonCount int         `json:"pokemon_count"`
+}
+
+type TypeListResponse struct {
+	Count int        `json:"count"`
+	Types []TypeInfo `json:"types"`
+}
+
+// attacking: এই টাইপের আক্রমণের গুণক প্রতিটি টাইপের বিরুদ্ধে; defending: প্রতিটি টাইপের আক্রমণ এর বিরুদ্ধে
+type TypeDetailResponse struct {
+	Type      TypeInfo           `json:"type"`
+	Attacking map[string]float64 `json:"attacking"`
+	Defending map[string]float64 `json:"defending"`
+}
+
+// missing: হিসাবে আছে কিন্তু সংরক্ষিত নেই; extra: সংরক্ষিত আছে কিন্তু হিসাবে নেই
+type WeaknessMismatch struct {
+	ID           int      `json:"id"`
+	Num          string   `json:"num"`
+	Name         string   `json:"name"`
+	Type         []string `json:"type"`
+	Stored       []string `json:"stored"`
+	Computed     []string `json:"computed"`
+	Missing      []string `json:"missing,omitempty"`
+	Extra        []string `json:"extra,omitempty"`
+	UnknownTypes []string `json:"unknown_types,omitempty"`
+	Fixed        bool     `json:"fixed"`
+}
+
+type WeaknessAuditResponse struct {
+	Checked    int                `json:"checked"`
+	Mismatched int                `json:"mismatched"`
+	Fixed      int                `json:"fixed"`
+	Records    []WeaknessMismatch `json:"records"`
+}
+
+// defense দুটি টাইপ একসাথে গুণ করে হিসাব; stored_weaknesses রেকর্ডে সংরক্ষিত মান
+type PokemonTypeDefenseResponse struct {
+	ID               int         `json:"id"`
+	Num              string      `json:"num"`
+	Name             string      `json:"name"`
+	Type             []string    `json:"type"`
+	UnknownTypes     []string    `json:"unknown_types,omitempty"`
+	StoredWeaknesses []string    `json:"stored_weaknesses"`
+	Defense          TypeDefense `json:"defense"`
+}
+
+type MatchupSide struct {
+	ID   int      `json:"id"`
+	Num  string   `json:"num"`
+	Name string   `json:"name"`
+	Type []string `json:"type"`
+}
+
+// by_type: আক্রমণকারীর প্রতিটি টাইপ প্রতিপক্ষের টাইপের বিরুদ্ধে কত গুণ
+type MatchupMultipliers struct {
+	ByType   map[string]float64 `json:"by_type"`
+	Best     float64            `json:"best"`
+	BestType string             `json:"best_type"`
+}
+
+// offense: attacker -> defender, defense: defender -> attacker
+type MatchupResponse struct {
+	Attacker MatchupSide        `json:"attacker"`
+	Defender MatchupSide        `json:"defender"`
+	Offense  MatchupMultipliers `json:"offense"`
+	Defense  MatchupMultipliers `json:"defense"`
+	Verdict  string             `json:"verdict"`
+	Summary  string             `json:"summary"`
+}
+
+type TeamResponse struct {
+	Message string `json:"message"`
+	Team    Team   `json:"team"`
+}
+
+type TeamListResponse struct {
+	Count int    `json:"count"`
+	Teams []Team `json:"teams"`
+}
+
+// আক্রমণ টাইপ type এর বিরুদ্ধে কোন সদস্য দুর্বল, প্রতিরোধী বা ইমিউন (নাম)
+type TeamWeakness struct {
+	Type    string   `json:"type"`
+	Weak    []string `json:"weak"`
+	Resists []string `json:"resists"`
+	Immune  []string `json:"immune"`
+}
+
+// covers: ঢাকা না থাকা কোন টাইপ আঘাত করে; resists/weak_to: ভাগ করা দুর্বলতার মধ্যে
+type TeamSuggestion struct {
+	ID      int      `json:"id"`
+	Num     string   `json:"num"`
+	Name    string   `json:"name"`
+	Type    []string `json:"type"`
+	Score   int      `json:"score"`
+	Covers  []string `json:"covers"`
+	Resists []string `json:"resists"`
+	WeakTo  []string `json:"weak_to"`
+}
+
+type TeamAnalysisResponse struct {
+	Team             Team             `json:"team"`
+	Members          []MatchupSide    `json:"members"`
+	SharedWeaknesses []TeamWeakness   `json:"shared_weaknesses"`
+	UncoveredTypes   []string         `json:"uncovered_types"`
+	Suggestions      []TeamSuggestion `json:"suggestions"`
+}
+
+type IVValues struct {
+	Attack  int `json:"attack"`
+	Defense int `json:"defense"`
+	Stamina int `json:"stamina"`
+}
+
+// estimated_*: multipliers দিয়ে আনুমানিক; cp: পরের ধাপের base stats থাকলে সঠিক মান
+type EvolvedCP struct {
+	Num          string `json:"num"`
+	Name         string `json:"name"`
+	EstimatedMin *int   `json:"estimated_min"`
+	EstimatedMax *int   `json:"estimated_max"`
+	CP           *int   `json:"cp"`
+}
+
+type CPResponse struct {
+	ID         int         `json:"id"`
+	Num        string      `json:"num"`
+	Name       string      `json:"name"`
+	Level      float64     `json:"level"`
+	IV         IVValues    `json:"iv"`
+	CP         int         `json:"cp"`
+	HP         int         `json:"hp"`
+	Multiplier float64     `json:"cp_multiplier"`
+	Evolutions []EvolvedCP `json:"evolutions"`
+}
+
+type IVMatch struct {
+	Level   float64 `json:"level"`
+	Attack  int     `json:"attack"`
+	Defense int     `json:"defense"`
+	Stamina int     `json:"stamina"`
+	Percent float64 `json:"percent"`
+}
+
+type IVResponse struct {
+	ID         int       `json:"id"`
+	Num        string    `json:"num"`
+	Name       string    `json:"name"`
+	CP         int       `json:"cp"`
+	HP         int       `json:"hp"`
+	Count      int       `json:"count"`
+	MinPercent *float64  `json:"min_percent"`
+	MaxPercent *float64  `json:"max_percent"`
+	Matches    []IVMatch `json:"matches"`
+}
+
+type EvolutionPlanTarget struct {
+	ID   int    `json:"id"`
+	Num  string `json:"num"`
+	Name string `json:"name"`
+}
+
+// candy: এই ধাপের খরচ; remaining: এই ধাপ পর্যন্ত have এর বাইরে আর কত লাগবে (অজানা খরচ থাকলে null)
+type EvolutionPlanStep struct {
+	FromNum    string `json:"from_num"`
+	FromName   string `json:"from_name"`
+	ToNum      string `json:"to_num"`
+	ToName     string `json:"to_name"`
+	Candy      *int   `json:"candy"`
+	Remaining  *int   `json:"remaining"`
+	Affordable bool   `json:"affordable"`
+}
+
+type EvolutionPlan struct {
+	Target EvolutionPlanTarget `json:"target"`
+	Steps  []EvolutionPlanStep `json:"steps"`
+	Total  *int                `json:"total"`
+}
+
+// total: সব লক্ষ্যের মোট ক্যান্ডি, needed: have বাদে আর কত
+type EvolutionPlanResponse struct {
+	Candy      string          `json:"candy"`
+	Have       int             `json:"have"`
+	Plans      []EvolutionPlan `json:"plans"`
+	Total      *int            `json:"total"`
+	Needed     *int            `json:"needed"`
+	Affordable bool            `json:"affordable"`
+}
+
+// offset_minutes: at থেকে চিহ্নযুক্ত দূরত্ব, মধ্যরাত পেরিয়েও সবচেয়ে ছোটটি
+type SpawnCandidate struct {
+	ID            int     `json:"id"`
+	Num           string  `json:"num"`
+	Name          string  `json:"name"`
+	SpawnTime     string  `json:"spawn_time"`
+	OffsetMinutes int     `json:"offset_minutes"`
+	Weight        float64 `json:"weight"`
+	Score         float64 `json:"score"`
+	Probability   float64 `json:"probability"`
+}
+
+// count: window এর মধ্যে মোট রেকর্ড, spawns এ limit পর্যন্ত
+type SpawnWindowResponse struct {
+	At            string           `json:"at"`
+	WindowMinutes int              `json:"window_minutes"`
+	From          string           `json:"from"`
+	To            string           `json:"to"`
+	WeightBy      string           `json:"weight_by"`
+	Count         int              `json:"count"`
+	Spawns        []SpawnCandidate `json:"spawns"`
+}
+
+// share: মোট spawn_chance এর কত অংশ এই ঘণ্টায়
+type SpawnHour struct {
+	Hour        int     `json:"hour"`
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	Count       int     `json:"count"`
+	SpawnChance float64 `json:"spawn_chance"`
+	AvgSpawns   float64 `json:"avg_spawns"`
+	Share       float64 `json:"share"`
+}
+
+// unscheduled: spawn_time নেই বা "N/A"
+type SpawnHourlyResponse struct {
+	Scheduled   int         `json:"scheduled"`
+	Unscheduled int         `json:"unscheduled"`
+	Hours       []SpawnHour `json:"hours"`
+}
+
+type EggSummary struct {
+	Egg   string `json:"egg"`
+	Km    int    `json:"km"`
+	Count int    `json:"count"`
+}
+
+// unknown: egg খালি বা অচেনা মান
+type EggListResponse struct {
+	Eggs      []EggSummary `json:"eggs"`
+	NotInEggs int          `json:"not_in_eggs"`
+	Unknown   int          `json:"unknown"`
+}
+
+// probability: এই ডিম থেকে হ্যাচের আপেক্ষিক সম্ভাবনা, weight এর অনুপাতে
+type EggHatch struct {
+	ID          int      `json:"id"`
+	Num         string   `json:"num"`
+	Name        string   `json:"name"`
+	Type        []string `json:"type"`
+	Weight      float64  `json:"weight"`
+	Probability float64  `json:"probability"`
+}
+
+type EggPoolResponse struct {
+	Egg      string     `json:"egg"`
+	Km       int        `json:"km"`
+	WeightBy string     `json:"weight_by"`
+	Count    int        `json:"count"`
+	Pokemons []EggHatch `json:"pokemons"`
+}
+
+type EggHatchResult struct {
+	ID   int    `json:"id"`
+	Num  string `json:"num"`
+	Name string `json:"name"`
+}
+
+// প্রতিটি রেকর্ড কতবার হ্যাচ হয়েছে; একই নামের আলাদা রেকর্ড আলাদা থাকে
+type EggHatchTally struct {
+	ID    int    `json:"id"`
+	Num   string `json:"num"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// seed স্ট্রিং হিসেবে, কারণ uint64 JSON নম্বরে নিরাপদে ধরে না
+type EggHatchResponse struct {
+	Egg      string           `json:"egg"`
+	Seed     string           `json:"seed"`
+	WeightBy string           `json:"weight_by"`
+	Count    int              `json:"count"`
+	Hatches  []EggHatchResult `json:"hatches"`
+	Tally    []EggHatchTally  `json:"tally"`
+}
+
+type HomeResponse struct {
+	Message        string                 `json:"message"`
+	Version        string                 `json:"version"`
+	Docs           string                 `json:"docs"`
+	OpenAPI        string                 `json:"openapi"`
+	Endpoints      map[string]string      `json:"endpoints"`
+	ExamplePayload map[string]interface{} `json:"example_payload"`
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+type Suggestion struct {
+	ID    int    `json:"id"`
+	Num   string `json:"num"`
+	Name  string `json:"name"`
+	Match string `json:"match"` // name, num বা word
+}
+
+type SuggestResponse struct {
+	Query       string       `json:"query"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
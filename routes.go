@@ -0,0 +1,734 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// রাউট রেজিস্ট্রি - সার্ভার মাক্স, OpenAPI স্পেক, হোমপেজ ও স্টার্টআপ লিস্ট সবাই এখান থেকে নেয়
+type Route struct {
+	Pattern    string
+	Tag        string
+	Bare       bool // মেট্রিক্স/ট্রেসিং/CORS মিডলওয়্যার ছাড়া রেজিস্টার হবে
+	Operations []Operation
+}
+
+type Operation struct {
+	Method      string
+	Summary     string
+	Description string
+	Handler     http.HandlerFunc
+	PathParams  []Param
+	Query       []Param
+	RequestBody interface{} // nil মানে বডি নেই
+	Response    interface{} // সফল রেসপন্সের টাইপ
+	Status      int
+	ContentType string // ডিফল্ট application/json
+	Errors      []int
+}
+
+type Param struct {
+	Name        string
+	Type        string // string, integer, number, boolean
+	Description string
+	Example     interface{}
+	Required    bool
+}
+
+var (
+	pageParam   = Param{Name: "page", Type: "integer", Description: "Page number (1-based), offset pagination", Example: 1}
+	limitParam  = Param{Name: "limit", Type: "integer", Description: "Items per page (1-100, default 20)", Example: 20}
+	cursorParam = Param{
+		Name: "cursor",
+		Type: "string",
+		Description: "Opaque cursor from next_cursor/prev_cursor; pass an empty value to start cursor pagination. " +
+			"Cannot be combined with page.",
+	}
+
+	fieldsParam = Param{Name: "fields", Type: "string", Description: "Comma-separated fields to return. With units=imperial, height_m and weight_kg select height_in and weight_lb (either name works)", Example: "id,name,num,type"}
+	expandParam = Param{
+		Name:        "expand",
+		Type:        "string",
+		Description: "Replace evolution stubs with full records: next_evolution, prev_evolution. Expanded records get the same fields and units as the top-level record",
+		Example:     "next_evolution",
+	}
+
+	sortParam = Param{
+		Name: "sort",
+		Type: "string",
+		Description: "Comma-separated sort keys, prefix with - for descending. " +
+			"Fields: id, num, name, candy, egg, spawn_chance, avg_spawns, candy_count, height, weight, spawn_time, base_attack, base_defense, base_stamina, created_at, updated_at.",
+		Example: "-spawn_chance,name",
+	}
+
+	filterParam = Param{
+		Name: "filter",
+		Type: "string",
+		Description: "Filter expression combining field comparisons with AND, OR, NOT and parentheses. " +
+			"Fields: type, weakness, egg, candy, name, num, spawn_chance, avg_spawns, candy_count, height, weight, spawn_time, base_attack, base_defense, base_stamina, " +
+			"has (next_evolution, prev_evolution, evolution, candy_count, multipliers, egg). " +
+			"Operators: : = != > >= < <=; ranges as from..to; lists as a,b.",
+		Example: "type:Fire AND spawn_chance>0.1",
+	}
+)
+
+var unitsParam = Param{Name: "units", Type: "string", Description: "Unit system for height and weight in the response: metric (default) or imperial. Imperial formats height and weight as strings and replaces height_m/weight_kg with height_in/weight_lb; the conversion happens before fields are projected", Example: "imperial"}
+
+var facetsParam = Param{
+	Name:        "facets",
+	Type:        "string",
+	Description: "Comma-separated facets to count over the filtered (not paginated) result: type, weaknesses, egg, candy",
+	Example:     "type,weaknesses,egg",
+}
+
+// সব কালেকশন এন্ডপয়েন্টের কমন প্যারামিটার (listPokemons)
+var listParams = []Param{
+	{Name: "type", Type: "string", Description: "Filter by type", Example: "Fire"},
+	{Name: "search", Type: "string", Description: "Search by name or number", Example: "pika"},
+	filterParam,
+	sortParam,
+	pageParam,
+	cursorParam,
+	limitParam,
+	fieldsParam,
+	expandParam,
+	facetsParam,
+	unitsParam,
+}
+
+// ServeMux এ "/api/pokemons/{id}/evolution-chain" আর "/api/pokemons/type/{type}" কনফ্লিক্ট করে
+// (কোনোটিই বেশি নির্দিষ্ট নয়), তাই {id} এর সাবরিসোর্সগুলো একটি প্যাটার্নে নিবন্ধিত হয়ে
+// শেষ সেগমেন্ট দিয়ে ভাগ হয়। ডকুমেন্টেশন, মেট্রিক ও ট্রেসে রাউটের নিজের প্যাটার্নই থাকে।
+const subresourcePattern = "/api/pokemons/{id}/{subresource}"
+
+func pokemonSubresource(pattern string) (string, bool) {
+	name, ok := strings.CutPrefix(pattern, "/api/pokemons/{id}/")
+	if !ok || name == "" || strings.ContainsAny(name, "/{}") {
+		return "", false
+	}
+	return name, true
+}
+
+func apiRoutes() []Route {
+	idParam := Param{Name: "id", Type: "integer", Description: "Pokémon ID", Example: 1, Required: true}
+	distanceParam := Param{Name: "distance", Type: "string", Description: "Egg distance in km, e.g. 2, 2km or 2 km", Example: "2", Required: true}
+	hatchWeightParam := Param{Name: "weight", Type: "string", Description: "Weight by spawn_chance (default) or avg_spawns", Example: "spawn_chance"}
+	teamIDParam := Param{Name: "id", Type: "integer", Description: "Team ID", Example: 1, Required: true}
+	typeParam := Param{Name: "type", Type: "string", Description: "Pokémon type (case-insensitive)", Example: "Fire", Required: true}
+
+	return []Route{
+		{
+			Pattern: "/",
+			Tag:     "meta",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "API Documentation", Handler: homeHandler, Response: HomeResponse{}, Errors: []int{404}},
+			},
+		},
+		{
+			Pattern: "/openapi.json",
+			Tag:     "meta",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "OpenAPI 3.1 specification", Handler: openAPIHandler, Response: map[string]interface{}{}},
+			},
+		},
+		{
+			Pattern: "/docs",
+			Tag:     "meta",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "HTML API reference", Handler: docsHandler, ContentType: "text/html"},
+			},
+		},
+		{
+			Pattern: "/metrics",
+			Tag:     "meta",
+			Bare:    true,
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Prometheus metrics", Handler: metricsHandler().ServeHTTP, ContentType: "text/plain"},
+			},
+		},
+		{
+			Pattern: "/healthz",
+			Tag:     "meta",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Liveness check", Handler: healthzHandler, Response: HealthResponse{}},
+			},
+		},
+		{
+			Pattern: "/readyz",
+			Tag:     "meta",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Readiness check", Handler: readyzHandler, Response: ReadinessResponse{}, Errors: []int{503}},
+			},
+		},
+		{
+			Pattern: "/version",
+			Tag:     "meta",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Build and version info", Handler: versionHandler, Response: BuildInfo{}},
+			},
+		},
+		{
+			Pattern: "/api/stats",
+			Tag:     "stats",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Get Pokémon statistics", Handler: getStats, Response: StatsResponse{}},
+			},
+		},
+		{
+			Pattern: "/api/aggregate",
+			Tag:     "stats",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Group-by aggregation with metrics",
+					Description: "Groups the filtered records by one or more keys and computes metrics per group. " +
+						"Multi-valued keys (type, weaknesses) put a record in every group it belongs to, so group counts can add up to more than `total`. " +
+						"Records without a value for a key fall into a group where that key is null. " +
+						"Metrics skip records missing the field and are null when no record has it.",
+					Handler: aggregatePokemons,
+					Query: []Param{
+						{Name: "group_by", Type: "string", Description: "Comma-separated group keys: type, weaknesses, egg, candy. Omit for a single overall group", Example: "egg"},
+						{Name: "metrics", Type: "string", Description: "Comma-separated metrics: count, or sum/avg/min/max/median/p<N> of num, spawn_chance, avg_spawns, candy_count, height (m), weight (kg), base_attack, base_defense, base_stamina. Defaults to count", Example: "count,avg(spawn_chance),max(avg_spawns),p90(weight)"},
+						filterParam,
+					},
+					Response: AggregateResponse{},
+					Errors:   []int{400},
+				},
+			},
+		},
+		{
+			Pattern: "/api/spawns",
+			Tag:     "spawns",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Pokémon likely to spawn near a time of day",
+					Description: "Finds records whose spawn_time is within `window` of `at`, wrapping across midnight. " +
+						"Each is scored by its weight times how close it is (1 at `at`, falling linearly to the window's edge); " +
+						"`probability` is its share of the total score. Records without a spawn time are skipped.",
+					Handler: getSpawns,
+					Query: []Param{
+						{Name: "at", Type: "string", Description: "Time of day as HH:MM", Example: "19:30", Required: true},
+						{Name: "window", Type: "string", Description: "How far either side of `at`, as a duration between 1m and 12h (default 60m)", Example: "60m"},
+						{Name: "weight", Type: "string", Description: "Weight by spawn_chance (default) or avg_spawns", Example: "spawn_chance"},
+						{Name: "limit", Type: "integer", Description: fmt.Sprintf("Maximum results, 1-%d (default %d)", maxSpawnLimit, defaultSpawnLimit), Example: 10},
+					},
+					Response: SpawnWindowResponse{},
+					Errors:   []int{400},
+				},
+			},
+		},
+		{
+			Pattern: "/api/spawns/hourly",
+			Tag:     "spawns",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Spawn counts and weights per hour of day", Handler: getSpawnsHourly, Response: SpawnHourlyResponse{}},
+			},
+		},
+		{
+			Pattern: "/api/eggs",
+			Tag:     "eggs",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Egg distances and how many Pokémon hatch from each", Handler: getEggs, Response: EggListResponse{}},
+			},
+		},
+		{
+			Pattern: "/api/eggs/{distance}",
+			Tag:     "eggs",
+			Operations: []Operation{
+				{
+					Method:      http.MethodGet,
+					Summary:     "Pokémon that hatch from an egg, with relative probabilities",
+					Description: "Probabilities are proportional to each Pokémon's `weight` field and equal when all weights are zero.",
+					Handler:     getEggPool,
+					PathParams:  []Param{distanceParam},
+					Query:       []Param{hatchWeightParam},
+					Response:    EggPoolResponse{},
+					Errors:      []int{400, 404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/eggs/{distance}/hatch",
+			Tag:     "eggs",
+			Operations: []Operation{
+				{
+					Method:      http.MethodGet,
+					Summary:     "Simulate hatching eggs",
+					Description: "Draws from the egg's pool by probability. The same `seed`, `count` and data always give the same hatches; without a seed one is chosen and returned.",
+					Handler:     hatchEgg,
+					PathParams:  []Param{distanceParam},
+					Query: []Param{
+						{Name: "count", Type: "integer", Description: fmt.Sprintf("Eggs to hatch, 1-%d (default %d)", maxHatchCount, defaultHatchCount), Example: 10},
+						{Name: "seed", Type: "integer", Description: "Random seed for reproducible results", Example: 42},
+						hatchWeightParam,
+					},
+					Response: EggHatchResponse{},
+					Errors:   []int{400, 404, 409},
+				},
+			},
+		},
+		{
+			Pattern: "/api/types",
+			Tag:     "types",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Type chart for all 18 types", Handler: getTypes, Response: TypeListResponse{}},
+			},
+		},
+		{
+			Pattern: "/api/types/{type}",
+			Tag:     "types",
+			Operations: []Operation{
+				{
+					Method:     http.MethodGet,
+					Summary:    "Offensive and defensive multipliers for one type",
+					Handler:    getType,
+					PathParams: []Param{{Name: "type", Type: "string", Description: "Type name (case-insensitive)", Example: "Fire", Required: true}},
+					Response:   TypeDetailResponse{},
+					Errors:     []int{404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/matchup",
+			Tag:     "types",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Compare two Pokémon by type effectiveness",
+					Description: "Each side's types are treated as its attacks. `offense` is the attacker's multipliers against the defender, `defense` the defender's against the attacker. " +
+						"The verdict compares the best multiplier on each side: attacker_favored, defender_favored or even.",
+					Handler: getMatchup,
+					Query: []Param{
+						{Name: "attacker", Type: "integer", Description: "Attacking Pokémon ID", Example: 4, Required: true},
+						{Name: "defender", Type: "integer", Description: "Defending Pokémon ID", Example: 1, Required: true},
+					},
+					Response: MatchupResponse{},
+					Errors:   []int{400, 404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/{id}/evolution-plan",
+			Tag:     "evolution",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Candy still needed to evolve into this Pokémon",
+					Description: "Walks the chain from the family's base to the target; each step costs the candy_count of the Pokémon being evolved. " +
+						"Extra `targets` must share the candy family and each starts from its own base Pokémon, so their costs add up. " +
+						"Steps are paid from `have` in order, targets in the order given. Totals are null when a step's cost is unknown.",
+					Handler:    getEvolutionPlan,
+					PathParams: []Param{idParam},
+					Query: []Param{
+						{Name: "have", Type: "integer", Description: "Candy the trainer already has (default 0)", Example: 40},
+						{Name: "targets", Type: "string", Description: "Comma-separated IDs of more targets in the same candy family", Example: "2"},
+					},
+					Response: EvolutionPlanResponse{},
+					Errors:   []int{400, 404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/{id}/cp",
+			Tag:     "calculator",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "CP and HP at a given level and IVs",
+					Description: "Uses the Pokémon GO formula with base_attack, base_defense and base_stamina. " +
+						"For each direct evolution, `estimated_min`/`estimated_max` scale the CP by this record's multipliers and `cp` is exact when the evolution has base stats.",
+					Handler:    getPokemonCP,
+					PathParams: []Param{idParam},
+					Query: []Param{
+						{Name: "level", Type: "number", Description: fmt.Sprintf("Level %g-%g in steps of 0.5 (default 20)", minLevel, maxLevel), Example: 20},
+						{Name: "attack", Type: "integer", Description: "Attack IV 0-15 (default 15)", Example: 15},
+						{Name: "defense", Type: "integer", Description: "Defense IV 0-15 (default 15)", Example: 15},
+						{Name: "stamina", Type: "integer", Description: "Stamina IV 0-15 (default 15)", Example: 15},
+					},
+					Response: CPResponse{},
+					Errors:   []int{400, 404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/{id}/iv",
+			Tag:     "calculator",
+			Operations: []Operation{
+				{
+					Method:      http.MethodGet,
+					Summary:     "Possible IV combinations for an observed CP and HP",
+					Description: "Searches every level (or only `level`) and IV combination that gives exactly this CP and HP, best IVs first.",
+					Handler:     getPokemonIVs,
+					PathParams:  []Param{idParam},
+					Query: []Param{
+						{Name: "cp", Type: "integer", Description: "Observed CP", Example: 637, Required: true},
+						{Name: "hp", Type: "integer", Description: "Observed HP", Example: 85, Required: true},
+						{Name: "level", Type: "number", Description: "Known level, e.g. 20 for a research or raid catch", Example: 20},
+					},
+					Response: IVResponse{},
+					Errors:   []int{400, 404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/teams",
+			Tag:     "teams",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Get all teams", Handler: getTeams, Response: TeamListResponse{}},
+				{
+					Method:      http.MethodPost,
+					Summary:     "Create a team",
+					Description: fmt.Sprintf("A team has a name and up to %d existing Pokémon IDs.", maxTeamSize),
+					Handler:     createTeam,
+					RequestBody: Team{},
+					Response:    TeamResponse{},
+					Status:      http.StatusCreated,
+					Errors:      []int{400},
+				},
+			},
+		},
+		{
+			Pattern: "/api/teams/{id}",
+			Tag:     "teams",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Get a team", Handler: getTeamByID, PathParams: []Param{teamIDParam}, Response: Team{}, Errors: []int{400, 404}},
+				{
+					Method:      http.MethodPut,
+					Summary:     "Replace a team",
+					Handler:     updateTeam,
+					PathParams:  []Param{teamIDParam},
+					RequestBody: Team{},
+					Response:    TeamResponse{},
+					Errors:      []int{400, 404},
+				},
+				{Method: http.MethodDelete, Summary: "Delete a team", Handler: deleteTeam, PathParams: []Param{teamIDParam}, Response: DeleteResponse{}, Errors: []int{400, 404}},
+			},
+		},
+		{
+			Pattern: "/api/teams/{id}/analysis",
+			Tag:     "teams",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Type coverage analysis for a team",
+					Description: "Shared weaknesses are attacking types that two or more members are weak to, with more weak members than resistant or immune ones. " +
+						"Weaknesses come from each member's stored weaknesses (computed from its type when empty). " +
+						"Uncovered types are defending types no member's own type hits super-effectively. " +
+						"Suggestions rank other Pokémon by uncovered types they hit plus shared weaknesses they resist, minus shared weaknesses they also have; they are empty for a full team.",
+					Handler:    getTeamAnalysis,
+					PathParams: []Param{teamIDParam},
+					Query: []Param{
+						{Name: "limit", Type: "integer", Description: fmt.Sprintf("Maximum suggestions, 1-%d (default %d)", maxTeamSuggestLimit, defaultTeamSuggestion), Example: 5},
+					},
+					Response: TeamAnalysisResponse{},
+					Errors:   []int{400, 404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/weaknesses/audit",
+			Tag:     "types",
+			Operations: []Operation{
+				{
+					Method:      http.MethodGet,
+					Summary:     "Flag stored weaknesses that disagree with the type chart",
+					Description: "Compares each record's weaknesses with the ones computed from its type (dual types multiply). Records without a type are skipped.",
+					Handler:     auditWeaknesses,
+					Response:    WeaknessAuditResponse{},
+				},
+				{
+					Method:      http.MethodPost,
+					Summary:     "Correct stored weaknesses from the type chart",
+					Description: "Replaces mismatched weaknesses with the computed ones and saves. Records with an unknown type are reported but left unchanged.",
+					Handler:     auditWeaknesses,
+					Response:    WeaknessAuditResponse{},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/{id}/type-defense",
+			Tag:     "types",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Weaknesses, resistances and immunities of a Pokémon",
+					Description: "Computed from the record's type with dual types multiplied, so a type can be a 4x weakness or cancel out to neutral. " +
+						"`multipliers` lists every attacking type that is not 1x, including 0 for immunities. Unknown types are reported and count as neutral.",
+					Handler:    getPokemonTypeDefense,
+					PathParams: []Param{idParam},
+					Response:   PokemonTypeDefenseResponse{},
+					Errors:     []int{400, 404},
+				},
+			},
+		},
+		{
+			Pattern: "/api/schema/pokemon",
+			Tag:     "meta",
+			Operations: []Operation{
+				{
+					Method:      http.MethodGet,
+					Summary:     "JSON Schema (draft 2020-12) for the Pokemon resource",
+					Description: "The server validates create and update bodies against this schema.",
+					Handler:     getPokemonSchema,
+					Response:    map[string]interface{}{},
+					ContentType: "application/schema+json",
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons",
+			Tag:     "pokemons",
+			Operations: []Operation{
+				{
+					Method:   http.MethodGet,
+					Summary:  "Get all Pokémon (with pagination)",
+					Handler:  getAllPokemons,
+					Response: PokemonListResponse{},
+					Query:    listParams,
+					Errors:   []int{400},
+				},
+				{
+					Method:      http.MethodPost,
+					Summary:     "Create new Pokémon",
+					Handler:     createPokemon,
+					RequestBody: Pokemon{},
+					Response:    PokemonResponse{},
+					Status:      http.StatusCreated,
+					Errors:      []int{400, 409},
+				},
+				{
+					Method:   http.MethodDelete,
+					Summary:  "Delete all Pokémon",
+					Handler:  deleteAllPokemons,
+					Response: DeleteAllResponse{},
+					Query: []Param{
+						{Name: "confirm", Type: "boolean", Description: "Must be true to confirm deletion", Example: true, Required: true},
+					},
+					Errors: []int{400},
+				},
+			},
+		},
+		{
+			// "/api/pokemons/" ট্রেইলিং স্ল্যাশ সহ পুরনো ক্লায়েন্টদের জন্য
+			Pattern: "/api/pokemons/{$}",
+			Tag:     "pokemons",
+			Operations: []Operation{
+				{
+					Method:      http.MethodGet,
+					Summary:     "Get all Pokémon (trailing-slash alias)",
+					Description: "Same as `GET /api/pokemons`.",
+					Handler:     getAllPokemons,
+					Response:    PokemonListResponse{},
+					Query:       listParams,
+					Errors:      []int{400},
+				},
+				{
+					Method:      http.MethodPost,
+					Summary:     "Create new Pokémon (trailing-slash alias)",
+					Description: "Same as `POST /api/pokemons`.",
+					Handler:     createPokemon,
+					RequestBody: Pokemon{},
+					Response:    PokemonResponse{},
+					Status:      http.StatusCreated,
+					Errors:      []int{400, 409},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/bulk",
+			Tag:     "pokemons",
+			Operations: []Operation{
+				{
+					Method:      http.MethodPost,
+					Summary:     "Bulk create Pokémon",
+					Description: "Returns 206 when some of the records could not be created. Evolution entries may refer to other records in the same batch.",
+					Handler:     bulkCreatePokemons,
+					RequestBody: []Pokemon{},
+					Response:    BulkCreateResponse{},
+					Status:      http.StatusCreated,
+					Errors:      []int{400},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/type/{type}",
+			Tag:     "queries",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Get Pokémon by type", Handler: getPokemonsByType, PathParams: []Param{typeParam}, Query: listParams, Response: PokemonListResponse{}, Errors: []int{400}},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/weakness/{type}",
+			Tag:     "queries",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Get Pokémon weak against a type", Handler: getPokemonsWeakAgainst, PathParams: []Param{typeParam}, Query: listParams, Response: PokemonListResponse{}, Errors: []int{400}},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/search/{query}",
+			Tag:     "queries",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Full-text search with typo tolerance",
+					Description: "Ranked search over num, name, type, candy, weaknesses and egg. Numbers match exactly or by prefix, with or without leading zeros. Misspellings (\"charmandr\"), prefixes and split words (\"bulba saur\") still match. " +
+						"Results are ordered by relevance unless `sort` is given; each item carries `_score` and `_highlights` with matched words wrapped in `<em>`.",
+					Handler:    searchPokemons,
+					PathParams: []Param{{Name: "query", Type: "string", Description: "Search text", Example: "char", Required: true}},
+					Query:      listParams,
+					Response:   PokemonListResponse{},
+					Errors:     []int{400},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/suggest",
+			Tag:     "queries",
+			Operations: []Operation{
+				{
+					Method:      http.MethodGet,
+					Summary:     "Autocomplete names and numbers",
+					Description: "Prefix suggestions for as-you-type search. Names and numbers match first, shorter completions before longer ones; matches on a later word of a name (\"mime\" for Mr. Mime) fill any remaining slots.",
+					Handler:     suggestPokemons,
+					Query: []Param{
+						{Name: "q", Type: "string", Description: "Typed prefix of a name, a word in the name or a number", Example: "cha", Required: true},
+						{Name: "limit", Type: "integer", Description: "Maximum suggestions (1-20)", Example: 5},
+					},
+					Response: SuggestResponse{},
+					Errors:   []int{400},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/{id}",
+			Tag:     "pokemons",
+			Operations: []Operation{
+				{Method: http.MethodGet, Summary: "Get Pokémon by ID", Handler: getPokemonByID, PathParams: []Param{idParam}, Query: []Param{fieldsParam, expandParam, unitsParam}, Response: Pokemon{}, Errors: []int{400, 404}},
+				{
+					Method:      http.MethodPut,
+					Summary:     "Update Pokémon (full)",
+					Description: "Evolution entries must name existing Pokémon. A changed name or num is copied into other Pokémon's evolution entries; updated_links counts them.",
+					Handler:     updatePokemon,
+					PathParams:  []Param{idParam},
+					RequestBody: Pokemon{},
+					Response:    PokemonResponse{},
+					Errors:      []int{400, 404, 409},
+				},
+				{
+					Method:      http.MethodPatch,
+					Summary:     "Update Pokémon (partial)",
+					Description: "Evolution entries are checked when prev_evolution or next_evolution is part of the patch. A changed name or num is copied into other Pokémon's evolution entries.",
+					Handler:     patchPokemon,
+					PathParams:  []Param{idParam},
+					RequestBody: map[string]interface{}{},
+					Response:    PokemonResponse{},
+					Errors:      []int{400, 404, 409},
+				},
+				{
+					Method:      http.MethodDelete,
+					Summary:     "Delete Pokémon by ID",
+					Description: "With on_delete=restrict the delete fails with 409 while other Pokémon list this one in their evolutions. The default cascade-unlink removes those entries and reports the count in unlinked.",
+					Handler:     deletePokemon,
+					PathParams:  []Param{idParam},
+					Query:       []Param{{Name: "on_delete", Type: "string", Description: "restrict or cascade-unlink (default)", Example: "restrict"}},
+					Response:    DeleteResponse{},
+					Errors:      []int{400, 404, 409},
+				},
+			},
+		},
+		{
+			Pattern: "/api/pokemons/{id}/evolution-chain",
+			Tag:     "evolution",
+			Operations: []Operation{
+				{
+					Method:  http.MethodGet,
+					Summary: "Full evolution family tree",
+					Description: "Returns the whole family from its base form, including branches, built from the evolution graph keyed by num. " +
+						"Each step carries its candy cost from the previous stage's candy_count. Stages referenced by another record but missing from the dataset are marked missing.",
+					Handler:    getEvolutionChain,
+					PathParams: []Param{idParam},
+					Response:   EvolutionChainResponse{},
+					Errors:     []int{400, 404},
+				},
+			},
+		},
+	}
+}
+
+// মেথড অনুযায়ী অপারেশন বাছাই
+func (rt Route) handler() http.HandlerFunc {
+	allowed := make([]string, 0, len(rt.Operations))
+	for _, op := range rt.Operations {
+		allowed = append(allowed, op.Method)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, op := range rt.Operations {
+			if op.Method == r.Method {
+				op.Handler(w, r)
+				return
+			}
+		}
+
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}.$]+)(\.\.\.)?\}`)
+
+// প্রতিটি রাউট ও অপারেশনের ডকুমেন্টেশন সম্পূর্ণ কিনা, আর মাক্সে নিবন্ধিত প্রতিটি প্যাটার্ন রেজিস্ট্রিতে আছে কিনা যাচাই
+func checkRouteDocs(routes []Route, patterns []string) error {
+	seen := make(map[string]bool)
+	documented := make(map[string]bool)
+
+	for _, rt := range routes {
+		if len(rt.Operations) == 0 {
+			return fmt.Errorf("route %s has no operations", rt.Pattern)
+		}
+		if rt.Tag == "" {
+			return fmt.Errorf("route %s has no tag", rt.Pattern)
+		}
+
+		for _, op := range rt.Operations {
+			key := op.Method + " " + rt.Pattern
+			if seen[key] {
+				return fmt.Errorf("%s is registered twice", key)
+			}
+			seen[key] = true
+			documented[rt.Pattern] = true
+
+			if op.Handler == nil {
+				return fmt.Errorf("%s has no handler", key)
+			}
+			if op.Summary == "" {
+				return fmt.Errorf("%s has no summary", key)
+			}
+			if op.Response == nil && op.ContentType == "" {
+				return fmt.Errorf("%s has no documented response", key)
+			}
+
+			params := make(map[string]bool)
+			for _, p := range op.PathParams {
+				params[p.Name] = true
+			}
+			for _, m := range pathParamPattern.FindAllStringSubmatch(rt.Pattern, -1) {
+				if !params[m[1]] {
+					return fmt.Errorf("%s does not document path parameter %q", key, m[1])
+				}
+			}
+		}
+	}
+
+	// ডিসপ্যাচার রাউটিংয়ের ভেতরের ব্যাপার, API নয়; এর সাবরিসোর্সগুলো নিজ নিজ প্যাটার্নে ডকুমেন্টেড
+	for _, pattern := range patterns {
+		if pattern != subresourcePattern && !documented[pattern] {
+			return fmt.Errorf("pattern %s is registered but not in the route registry", pattern)
+		}
+	}
+
+	return nil
+}
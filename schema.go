@@ -0,0 +1,376 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Pokemon রিসোর্সের JSON Schema (draft 2020-12)
+//
+// সার্ভার ইনকামিং বডি এই স্কিমা দিয়েই যাচাই করে, তাই প্রকাশিত স্কিমা ও সার্ভারের নিয়ম কখনো আলাদা হয় না।
+// ref দিয়ে ঠিক করা হয় Evolution কোথায় থাকবে ($defs নাকি OpenAPI components)।
+func pokemonSchemaObject(ref func(name string) string) map[string]interface{} {
+	stringList := map[string]interface{}{
+		"type":        []string{"array", "null"},
+		"items":       map[string]interface{}{"type": "string", "minLength": 1},
+		"uniqueItems": true,
+	}
+	baseStat := map[string]interface{}{
+		"type":        []string{"integer", "null"},
+		"minimum":     1,
+		"description": "Pokémon GO base stat, used by the CP and IV calculators",
+	}
+	evolutionList := map[string]interface{}{
+		"type":  []string{"array", "null"},
+		"items": map[string]interface{}{"$ref": ref("Evolution")},
+	}
+
+	return map[string]interface{}{
+		"title":                "Pokemon",
+		"type":                 "object",
+		"required":             []string{"num", "name"},
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"id":   map[string]interface{}{"type": "integer", "readOnly": true},
+			"num":  map[string]interface{}{"type": "string", "pattern": `^[0-9]{1,4}$`, "description": "Pokédex number, e.g. 025"},
+			"name": map[string]interface{}{"type": "string", "minLength": 1, "maxLength": 50},
+			"img":  map[string]interface{}{"type": "string", "format": "uri"},
+			"type": map[string]interface{}{
+				"type":        []string{"array", "null"},
+				"items":       map[string]interface{}{"type": "string", "minLength": 1},
+				"maxItems":    2,
+				"uniqueItems": true,
+			},
+			"height": map[string]interface{}{
+				"type":        "string",
+				"pattern":     `^$|` + heightPattern,
+				"description": "Number with m, cm, in, or ft and optional in; parsed into height_m. Empty when unknown",
+				"examples":    []string{"0.71 m", "71 cm", "2 ft 4 in", "2'4\""},
+			},
+			"weight": map[string]interface{}{
+				"type":        "string",
+				"pattern":     `^$|` + weightPattern,
+				"description": "Number with kg or lb; parsed into weight_kg. Empty when unknown",
+				"examples":    []string{"6.9 kg", "15.2 lb"},
+			},
+			"height_m":    map[string]interface{}{"type": "number", "readOnly": true, "description": "Height in meters, parsed from height"},
+			"weight_kg":   map[string]interface{}{"type": "number", "readOnly": true, "description": "Weight in kilograms, parsed from weight"},
+			"candy":       map[string]interface{}{"type": "string"},
+			"candy_count": map[string]interface{}{"type": []string{"integer", "null"}, "minimum": 0},
+			"egg": map[string]interface{}{
+				"type":        "string",
+				"enum":        append([]string{""}, eggValues()...),
+				"description": "Egg distance; variants like 2km, 2 KM or 2 are stored as 2 km. Empty when unknown",
+			},
+			"spawn_chance": map[string]interface{}{"type": "number", "minimum": 0},
+			"avg_spawns":   map[string]interface{}{"type": "number", "minimum": 0},
+			"spawn_time":   map[string]interface{}{"type": "string", "pattern": `^(([01][0-9]|2[0-3]):[0-5][0-9]|N/A)?$`, "description": "Time of day as HH:MM, N/A, or empty when unknown"},
+			"multipliers": map[string]interface{}{
+				"type":  []string{"array", "null"},
+				"items": map[string]interface{}{"type": "number", "minimum": 0},
+			},
+			"base_attack":    baseStat,
+			"base_defense":   baseStat,
+			"base_stamina":   baseStat,
+			"weaknesses":     stringList,
+			"next_evolution": evolutionList,
+			"prev_evolution": evolutionList,
+			"created_at":     map[string]interface{}{"type": "string", "format": "date-time", "readOnly": true},
+			"updated_at":     map[string]interface{}{"type": "string", "format": "date-time", "readOnly": true},
+		},
+	}
+}
+
+func evolutionSchemaObject() map[string]interface{} {
+	return map[string]interface{}{
+		"title":                "Evolution",
+		"type":                 "object",
+		"required":             []string{"num", "name"},
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"num":  map[string]interface{}{"type": "string", "pattern": `^[0-9]{1,4}$`},
+			"name": map[string]interface{}{"type": "string", "minLength": 1},
+		},
+	}
+}
+
+// /api/schema/pokemon এ প্রকাশিত স্বয়ংসম্পূর্ণ ডকুমেন্ট
+var pokemonSchema = func() map[string]interface{} {
+	schema := pokemonSchemaObject(func(name string) string { return "#/$defs/" + name })
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = "/api/schema/pokemon"
+	schema["$defs"] = map[string]interface{}{
+		"Evolution": evolutionSchemaObject(),
+	}
+	return schema
+}()
+
+// GET /api/schema/pokemon
+func getPokemonSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(pokemonSchema)
+}
+
+// ==================== VALIDATION ====================
+
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// নতুন বা সম্পূর্ণ আপডেটের বডি যাচাই
+func validatePokemon(value interface{}) []ValidationError {
+	normalizeRawEgg(value)
+	v := schemaValidator{root: pokemonSchema}
+	v.validate(pokemonSchema, value, "")
+	return v.errors
+}
+
+// PATCH বডি যাচাই - শুধু পাঠানো ফিল্ডগুলো, required প্রযোজ্য নয়
+func validatePokemonPatch(updates map[string]interface{}) []ValidationError {
+	partial := make(map[string]interface{}, len(pokemonSchema))
+	for k, v := range pokemonSchema {
+		partial[k] = v
+	}
+	delete(partial, "required")
+	normalizeRawEgg(updates)
+
+	v := schemaValidator{root: pokemonSchema}
+	v.validate(partial, updates, "")
+	return v.errors
+}
+
+func respondValidationErrors(w http.ResponseWriter, errs []ValidationError) {
+	respondJSON(w, http.StatusBadRequest, ErrorResponse{
+		Error:   "Validation failed",
+		Details: errs,
+	})
+}
+
+// JSON Schema এর যে অংশটুকু আমাদের স্কিমায় ব্যবহৃত হয় তার ভ্যালিডেটর
+type schemaValidator struct {
+	root   map[string]interface{}
+	errors []ValidationError
+}
+
+var patternCache sync.Map // string -> *regexp.Regexp
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{Field: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(schema map[string]interface{}, value interface{}, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		defs, _ := v.root["$defs"].(map[string]interface{})
+		target, _ := defs[name].(map[string]interface{})
+		v.validate(target, value, path)
+		return
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "must be of type %s", typeList(t))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]string); ok {
+		s, _ := value.(string)
+		found := false
+		for _, e := range enum {
+			if s == e {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "must be one of %s", strings.Join(enum, ", "))
+		}
+	}
+
+	switch val := value.(type) {
+	case string:
+		if min, ok := schema["minLength"].(int); ok && len([]rune(val)) < min {
+			if min == 1 {
+				v.fail(path, "must not be empty")
+			} else {
+				v.fail(path, "must be at least %d characters", min)
+			}
+		}
+		if max, ok := schema["maxLength"].(int); ok && len([]rune(val)) > max {
+			v.fail(path, "must be at most %d characters", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !compilePattern(pattern).MatchString(val) {
+			v.fail(path, "must match pattern %s", pattern)
+		}
+
+	case float64:
+		if min, ok := toFloat(schema["minimum"]); ok && val < min {
+			v.fail(path, "must be >= %v", min)
+		}
+		if max, ok := toFloat(schema["maximum"]); ok && val > max {
+			v.fail(path, "must be <= %v", max)
+		}
+
+	case []interface{}:
+		if min, ok := schema["minItems"].(int); ok && len(val) < min {
+			v.fail(path, "must have at least %d items", min)
+		}
+		if max, ok := schema["maxItems"].(int); ok && len(val) > max {
+			v.fail(path, "must have at most %d items", max)
+		}
+		if unique, _ := schema["uniqueItems"].(bool); unique {
+			seen := make(map[string]bool)
+			for _, item := range val {
+				key, _ := json.Marshal(item)
+				if seen[string(key)] {
+					v.fail(path, "must not contain duplicate items")
+					break
+				}
+				seen[string(key)] = true
+			}
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range val {
+				v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+
+		if required, ok := schema["required"].([]string); ok {
+			for _, field := range required {
+				if _, present := val[field]; !present {
+					v.fail(joinPath(path, field), "is required")
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			prop, ok := properties[k].(map[string]interface{})
+			if !ok {
+				if allowed, ok := schema["additionalProperties"].(bool); ok && !allowed {
+					v.fail(joinPath(path, k), "unknown field")
+				}
+				continue
+			}
+			// সার্ভার-নিয়ন্ত্রিত ফিল্ড, পাঠালে উপেক্ষা করা হয়
+			if readOnly, _ := prop["readOnly"].(bool); readOnly {
+				continue
+			}
+			v.validate(prop, val[k], joinPath(path, k))
+		}
+	}
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patternCache.Store(pattern, re)
+	return re
+}
+
+func matchesType(t interface{}, value interface{}) bool {
+	switch t := t.(type) {
+	case string:
+		return matchesSingleType(t, value)
+	case []string:
+		for _, name := range t {
+			if matchesSingleType(name, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesSingleType(name string, value interface{}) bool {
+	switch name {
+	case "null":
+		return value == nil
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+func typeList(t interface{}) string {
+	if list, ok := t.([]string); ok {
+		return strings.Join(list, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// যাচাই করা JSON মানকে টাইপড স্ট্রাকচারে রূপান্তর
+func convertJSON(value interface{}, target interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
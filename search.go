@@ -0,0 +1,335 @@
+package main
+
+import (
+	"html"
+	"maps"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ==================== FULL-TEXT SEARCH ====================
+//
+// num, name, candy, type, weaknesses ও egg এর উপর ইনভার্টেড ইনডেক্স।
+// বানান ভুল ধরতে ট্রাইগ্রাম দিয়ে ক্যান্ডিডেট বের করে এডিট ডিসট্যান্স দিয়ে যাচাই করা হয়।
+// সংখ্যা (num) শুধু হুবহু বা prefix এ মেলে, "004" ও "4" দুটোই Charmander খুঁজে পায়।
+// ইনডেক্স db লকের অধীনে থাকে: লেখার সময় db.Lock, সার্চের সময় db.RLock ধরে রাখতে হবে।
+// আপডেট হয় indexes.go এর indexPokemon/unindexPokemon দিয়ে।
+
+type searchField struct {
+	name    string
+	weight  float64
+	numeric bool // শূন্য বাদ দেওয়া রূপও ইনডেক্সে যায়
+	values  func(p *Pokemon) []string
+}
+
+var searchFields = []searchField{
+	{name: "num", weight: 5, numeric: true, values: func(p *Pokemon) []string { return []string{p.Num} }},
+	{name: "name", weight: 5, values: func(p *Pokemon) []string { return []string{p.Name} }},
+	{name: "type", weight: 3, values: func(p *Pokemon) []string { return p.Type }},
+	{name: "candy", weight: 2, values: func(p *Pokemon) []string { return []string{p.Candy} }},
+	{name: "weaknesses", weight: 1, values: func(p *Pokemon) []string { return p.Weaknesses }},
+	{name: "egg", weight: 1, values: func(p *Pokemon) []string { return []string{p.Egg} }},
+}
+
+type posting struct {
+	field  string
+	weight float64
+}
+
+type searchIndex struct {
+	terms    map[string]map[int][]posting // term -> id -> কোন ফিল্ডে আছে
+	trigrams map[string]map[string]bool   // trigram -> terms
+	docTerms map[int][]string             // id -> terms, মুছে ফেলার জন্য
+}
+
+var searchIdx = newSearchIndex()
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		terms:    make(map[string]map[int][]posting),
+		trigrams: make(map[string]map[string]bool),
+		docTerms: make(map[int][]string),
+	}
+}
+
+func (idx *searchIndex) add(p *Pokemon) {
+	seen := make(map[string]bool)
+	for _, f := range searchFields {
+		for _, value := range f.values(p) {
+			for _, term := range f.terms(value) {
+				docs := idx.terms[term]
+				if docs == nil {
+					docs = make(map[int][]posting)
+					idx.terms[term] = docs
+					for _, tg := range trigramsOf(term) {
+						if idx.trigrams[tg] == nil {
+							idx.trigrams[tg] = make(map[string]bool)
+						}
+						idx.trigrams[tg][term] = true
+					}
+				}
+				docs[p.ID] = append(docs[p.ID], posting{field: f.name, weight: f.weight})
+				if !seen[term] {
+					seen[term] = true
+					idx.docTerms[p.ID] = append(idx.docTerms[p.ID], term)
+				}
+			}
+		}
+	}
+}
+
+func (idx *searchIndex) remove(id int) {
+	for _, term := range idx.docTerms[id] {
+		docs := idx.terms[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(idx.terms, term)
+			for _, tg := range trigramsOf(term) {
+				delete(idx.trigrams[tg], term)
+				if len(idx.trigrams[tg]) == 0 {
+					delete(idx.trigrams, tg)
+				}
+			}
+		}
+	}
+	delete(idx.docTerms, id)
+}
+
+// একটি রেকর্ডের সার্চ ফলাফল
+type searchHit struct {
+	score   float64
+	matched map[string]map[string]bool // field -> matched terms
+}
+
+// কোয়েরি টোকেনের সাথে মেলে এমন ইনডেক্সের টার্ম ও মিলের মান (0-1)
+func (idx *searchIndex) expand(token string) map[string]float64 {
+	candidates := make(map[string]float64)
+	consider := func(term string, quality float64) {
+		if quality > candidates[term] {
+			candidates[term] = quality
+		}
+	}
+
+	if _, ok := idx.terms[token]; ok {
+		consider(token, 1)
+	}
+	numeric := isDigits(token)
+
+	maxEdits := 0
+	switch n := len([]rune(token)); {
+	case n >= 8:
+		maxEdits = 2
+	case n >= 4:
+		maxEdits = 1
+	}
+
+	// ট্রাইগ্রাম শেয়ার করা টার্মগুলো থেকে prefix, substring ও টাইপো মিল
+	seen := make(map[string]bool)
+	for _, tg := range trigramsOf(token) {
+		for term := range idx.trigrams[tg] {
+			if seen[term] || term == token {
+				continue
+			}
+			seen[term] = true
+
+			ratio := float64(len(token)) / float64(len(term))
+			switch {
+			case strings.HasPrefix(term, token):
+				consider(term, 0.6+0.3*ratio)
+			case numeric:
+				// সংখ্যায় substring বা টাইপো মিল অর্থহীন, 025 খুঁজলে 026 নয়
+				continue
+			case len(token) >= 3 && strings.Contains(term, token):
+				consider(term, 0.3+0.3*ratio)
+			}
+			if maxEdits > 0 {
+				if d := editDistance(token, term); d <= maxEdits {
+					consider(term, 0.85-0.15*float64(d))
+				}
+			}
+		}
+	}
+
+	return candidates
+}
+
+func (idx *searchIndex) search(query string) map[int]*searchHit {
+	tokens := tokenize(query)
+	// "bulba saur" এর মতো ভাঙা শব্দের জন্য পাশাপাশি টোকেন জোড়া লাগিয়েও খোঁজা হয়
+	for i, n := 0, len(tokens); i+1 < n; i++ {
+		tokens = append(tokens, tokens[i]+tokens[i+1])
+	}
+
+	total := float64(len(idx.docTerms))
+	hits := make(map[int]*searchHit)
+
+	for _, token := range tokens {
+		best := make(map[int]float64)
+		matched := make(map[int][]posting)
+		matchedTerm := make(map[int][]string)
+
+		for term, quality := range idx.expand(token) {
+			docs := idx.terms[term]
+			idf := math.Log(1 + total/float64(len(docs)))
+			for id, postings := range docs {
+				for _, p := range postings {
+					score := quality * p.weight * idf
+					if score > best[id] {
+						best[id] = score
+					}
+					matched[id] = append(matched[id], p)
+					matchedTerm[id] = append(matchedTerm[id], term)
+				}
+			}
+		}
+
+		for id, score := range best {
+			hit := hits[id]
+			if hit == nil {
+				hit = &searchHit{matched: make(map[string]map[string]bool)}
+				hits[id] = hit
+			}
+			hit.score += score
+			for i, p := range matched[id] {
+				if hit.matched[p.field] == nil {
+					hit.matched[p.field] = make(map[string]bool)
+				}
+				hit.matched[p.field][matchedTerm[id][i]] = true
+			}
+		}
+	}
+
+	for _, hit := range hits {
+		hit.score = math.Round(hit.score*1000) / 1000
+	}
+	return hits
+}
+
+// মেলা শব্দগুলো <em> দিয়ে মোড়ানো ফিল্ড স্নিপেট
+func (hit *searchHit) highlights(p *Pokemon) map[string]string {
+	result := make(map[string]string)
+	for _, f := range searchFields {
+		terms := hit.matched[f.name]
+		if len(terms) == 0 {
+			continue
+		}
+
+		if f.numeric {
+			// "4" মিললে সংরক্ষিত "004" ও চিহ্নিত হয়
+			terms = maps.Clone(terms)
+			for _, value := range f.values(p) {
+				for _, token := range tokenize(value) {
+					for _, term := range f.terms(token) {
+						if hit.matched[f.name][term] {
+							terms[token] = true
+						}
+					}
+				}
+			}
+		}
+
+		var parts []string
+		for _, value := range f.values(p) {
+			parts = append(parts, highlightText(value, terms))
+		}
+		result[f.name] = strings.Join(parts, ", ")
+	}
+	return result
+}
+
+func highlightText(text string, terms map[string]bool) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !isTokenRune(runes[i]) {
+			b.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isTokenRune(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		if terms[strings.ToLower(word)] {
+			b.WriteString("<em>" + html.EscapeString(word) + "</em>")
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !isTokenRune(r)
+	})
+}
+
+// মানের ইনডেক্স টার্ম; সংখ্যার ফিল্ডে "004" এর সাথে "4" ও
+func (f searchField) terms(value string) []string {
+	terms := tokenize(value)
+	if !f.numeric {
+		return terms
+	}
+	for _, term := range terms {
+		if trimmed := strings.TrimLeft(term, "0"); trimmed != term && trimmed != "" {
+			terms = append(terms, trimmed)
+		}
+	}
+	return terms
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func trigramsOf(term string) []string {
+	runes := []rune("$" + term + "$")
+	if len(runes) < 3 {
+		return []string{string(runes)}
+	}
+	grams := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+3]))
+	}
+	return grams
+}
+
+// Damerau-Levenshtein (optimal string alignment) দূরত্ব
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func searchNames(t *testing.T, query string) []string {
+	t.Helper()
+	mux, _ := newServeMux()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/search/"+url.PathEscape(query), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("search %q: status %d, body %s", query, rec.Code, rec.Body)
+	}
+	var body struct {
+		Data []struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	names := make([]string, len(body.Data))
+	for i, d := range body.Data {
+		names[i] = d.Name
+	}
+	return names
+}
+
+func TestSearchRanksExpectedRecordFirst(t *testing.T) {
+	for _, tc := range []struct {
+		query, want string
+	}{
+		{"004", "Charmander"},
+		{"4", "Charmander"},
+		{"charmandr", "Charmander"},
+		{"bulba saur", "Bulbasaur"},
+		{"venusaur", "Venusaur"},
+	} {
+		names := searchNames(t, tc.query)
+		if len(names) == 0 || names[0] != tc.want {
+			t.Errorf("search %q: got %v, want %s first", tc.query, names, tc.want)
+		}
+	}
+}
+
+// সংখ্যায় টাইপো মিল নেই: 004 খুঁজলে 005 বা 006 আসে না
+func TestSearchNumIsExact(t *testing.T) {
+	if names := searchNames(t, "004"); len(names) != 1 {
+		t.Errorf("search 004: got %v, want only Charmander", names)
+	}
+	if names := searchNames(t, "999"); len(names) != 0 {
+		t.Errorf("search 999: got %v, want no results", names)
+	}
+}
+
+func TestSearchHighlightsNum(t *testing.T) {
+	mux, _ := newServeMux()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/search/4", nil))
+	var body struct {
+		Data []struct {
+			Highlights map[string]string `json:"_highlights"`
+		} `json:"data"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.Data) == 0 || body.Data[0].Highlights["num"] != "<em>004</em>" {
+		t.Errorf("search 4: highlights %+v, want num <em>004</em>", body.Data)
+	}
+}
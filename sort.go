@@ -0,0 +1,143 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ?sort=-spawn_chance,name এর একটি কী
+type sortKey struct {
+	field string
+	desc  bool
+	fn    sortValue // sortFields এর বাইরের কী, যেমন সার্চের relevance
+}
+
+func (k sortKey) value(p *Pokemon) (interface{}, bool) {
+	if k.fn != nil {
+		return k.fn(p)
+	}
+	return sortFields[k.field](p)
+}
+
+// মান না থাকলে ok=false, এমন রেকর্ড সবসময় শেষে যায়
+type sortValue func(p *Pokemon) (v interface{}, ok bool)
+
+var sortFields = map[string]sortValue{
+	"id":           func(p *Pokemon) (interface{}, bool) { return float64(p.ID), true },
+	"num":          func(p *Pokemon) (interface{}, bool) { return parseNumber(p.Num) },
+	"name":         func(p *Pokemon) (interface{}, bool) { return strings.ToLower(p.Name), true },
+	"candy":        func(p *Pokemon) (interface{}, bool) { return strings.ToLower(p.Candy), p.Candy != "" },
+	"egg":          func(p *Pokemon) (interface{}, bool) { return strings.ToLower(p.Egg), p.Egg != "" },
+	"spawn_chance": func(p *Pokemon) (interface{}, bool) { return p.SpawnChance, true },
+	"avg_spawns":   func(p *Pokemon) (interface{}, bool) { return p.AvgSpawns, true },
+	"candy_count": func(p *Pokemon) (interface{}, bool) {
+		if p.CandyCount == nil {
+			return nil, false
+		}
+		return float64(*p.CandyCount), true
+	},
+	"base_attack":  func(p *Pokemon) (interface{}, bool) { return stat(p.BaseAttack) },
+	"base_defense": func(p *Pokemon) (interface{}, bool) { return stat(p.BaseDefense) },
+	"base_stamina": func(p *Pokemon) (interface{}, bool) { return stat(p.BaseStamina) },
+	"height":       func(p *Pokemon) (interface{}, bool) { return measure(p.HeightM) },
+	"weight":       func(p *Pokemon) (interface{}, bool) { return measure(p.WeightKg) },
+	"spawn_time":   func(p *Pokemon) (interface{}, bool) { return parseClock(p.SpawnTime) },
+	"created_at":   func(p *Pokemon) (interface{}, bool) { return float64(p.CreatedAt.UnixNano()), true },
+	"updated_at":   func(p *Pokemon) (interface{}, bool) { return float64(p.UpdatedAt.UnixNano()), true },
+}
+
+func parseSort(s string) ([]sortKey, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var keys []sortKey
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		key := sortKey{field: part}
+		if strings.HasPrefix(part, "-") {
+			key = sortKey{field: part[1:], desc: true}
+		} else if strings.HasPrefix(part, "+") {
+			key.field = part[1:]
+		}
+		key.field = strings.ToLower(key.field)
+
+		if key.field == "" {
+			return nil, fmt.Errorf("empty sort field")
+		}
+		if _, ok := sortFields[key.field]; !ok {
+			return nil, fmt.Errorf("unknown sort field %q", key.field)
+		}
+		if seen[key.field] {
+			return nil, fmt.Errorf("sort field %q given more than once", key.field)
+		}
+		seen[key.field] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// রিকোয়েস্ট থেকে sort প্যারামিটার, ভুল হলে 400 পাঠিয়ে false ফেরত দেয়
+func sortFromRequest(w http.ResponseWriter, r *http.Request) ([]sortKey, bool) {
+	keys, err := parseSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid sort",
+			Details: []ValidationError{{Field: "sort", Message: err.Error()}},
+		})
+		return nil, false
+	}
+	return keys, true
+}
+
+// স্টেবল মাল্টি-কী সর্ট, সবশেষে ID দিয়ে টাই ভাঙে
+func sortPokemons(list []Pokemon, keys []sortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(list, func(a, b Pokemon) int {
+		for _, key := range keys {
+			if c := compareField(&a, &b, key); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+}
+
+func compareField(a, b *Pokemon, key sortKey) int {
+	av, aok := key.value(a)
+	bv, bok := key.value(b)
+	return compareValues(av, aok, bv, bok, key.desc)
+}
+
+func compareValues(av interface{}, aok bool, bv interface{}, bok bool, desc bool) int {
+	switch {
+	case !aok && !bok:
+		return 0
+	case !aok:
+		return 1
+	case !bok:
+		return -1
+	}
+
+	var c int
+	switch av := av.(type) {
+	case float64:
+		bf, _ := bv.(float64)
+		c = cmp.Compare(av, bf)
+	case string:
+		bs, _ := bv.(string)
+		c = cmp.Compare(av, bs)
+	}
+
+	if desc {
+		return -c
+	}
+	return c
+}
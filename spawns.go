@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ==================== SPAWN TIMES ====================
+//
+// spawn_time ("20:00") দিনের মিনিটে পার্স করে মিনিট অনুযায়ী সাজানো ইনডেক্সে রাখা হয়।
+// সময়ের দূরত্ব বৃত্তাকার, তাই 23:50 ও 00:10 এর মধ্যে 20 মিনিট। "N/A" বা ভুল মান ইনডেক্সে থাকে না।
+
+const (
+	minutesPerDay      = 24 * 60
+	defaultSpawnWindow = 60 * time.Minute
+	maxSpawnWindow     = 12 * time.Hour
+	defaultSpawnLimit  = 20
+	maxSpawnLimit      = 100
+)
+
+type spawnEntry struct {
+	minute int
+	id     int
+}
+
+type spawnIndex struct {
+	entries []spawnEntry // minute, তারপর id অনুযায়ী সাজানো
+	minutes map[int]int  // id -> minute
+}
+
+var spawnIdx = newSpawnIndex()
+
+func newSpawnIndex() *spawnIndex {
+	return &spawnIndex{minutes: make(map[int]int)}
+}
+
+func (s *spawnIndex) add(p *Pokemon) {
+	m, ok := parseClock(p.SpawnTime)
+	if !ok {
+		return
+	}
+	e := spawnEntry{minute: int(m), id: p.ID}
+	i := sort.Search(len(s.entries), func(i int) bool { return !s.entries[i].less(e) })
+	s.entries = append(s.entries, spawnEntry{})
+	copy(s.entries[i+1:], s.entries[i:])
+	s.entries[i] = e
+	s.minutes[p.ID] = e.minute
+}
+
+func (s *spawnIndex) remove(id int) {
+	m, ok := s.minutes[id]
+	if !ok {
+		return
+	}
+	e := spawnEntry{minute: m, id: id}
+	i := sort.Search(len(s.entries), func(i int) bool { return !s.entries[i].less(e) })
+	if i < len(s.entries) && s.entries[i] == e {
+		s.entries = append(s.entries[:i], s.entries[i+1:]...)
+	}
+	delete(s.minutes, id)
+}
+
+func (a spawnEntry) less(b spawnEntry) bool {
+	if a.minute != b.minute {
+		return a.minute < b.minute
+	}
+	return a.id < b.id
+}
+
+// [from, to] মিনিটের মধ্যের এন্ট্রি; from > to হলে মধ্যরাত পেরিয়ে যায়
+func (s *spawnIndex) between(from, to int) []spawnEntry {
+	slice := func(lo, hi int) []spawnEntry {
+		i := sort.Search(len(s.entries), func(i int) bool { return s.entries[i].minute >= lo })
+		j := sort.Search(len(s.entries), func(i int) bool { return s.entries[i].minute > hi })
+		return s.entries[i:j]
+	}
+	if from <= to {
+		return slice(from, to)
+	}
+	return append(append([]spawnEntry{}, slice(from, minutesPerDay-1)...), slice(0, to)...)
+}
+
+// at থেকে minute পর্যন্ত সবচেয়ে ছোট চিহ্নযুক্ত দূরত্ব, -720..720
+func clockOffset(at, minute int) int {
+	d := ((minute-at)%minutesPerDay + minutesPerDay) % minutesPerDay
+	if d > minutesPerDay/2 {
+		d -= minutesPerDay
+	}
+	return d
+}
+
+func formatClock(minute int) string {
+	minute = wrapMinute(minute)
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// ওজনের ভিত্তি: spawn_chance (ডিফল্ট) বা avg_spawns
+var spawnWeights = map[string]func(p *Pokemon) float64{
+	"spawn_chance": func(p *Pokemon) float64 { return p.SpawnChance },
+	"avg_spawns":   func(p *Pokemon) float64 { return p.AvgSpawns },
+}
+
+// GET /api/spawns?at=19:30&window=60m
+//
+// window এর মধ্যে প্রতিটি রেকর্ডের score = ওজন × (1 - |offset| / (window + 1)), মিনিটে,
+// যাতে ঠিক সীমায় থাকা রেকর্ডও বাদ না পড়ে;
+// probability হলো score কে সব score এর যোগফল দিয়ে ভাগ।
+func getSpawns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	var errs []ValidationError
+
+	at, ok := parseClock(query.Get("at"))
+	if !ok {
+		errs = append(errs, ValidationError{Field: "at", Message: "must be a time of day as HH:MM"})
+	}
+	window := defaultSpawnWindow
+	if s := query.Get("window"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < time.Minute || d > maxSpawnWindow {
+			errs = append(errs, ValidationError{Field: "window", Message: fmt.Sprintf("must be a duration between 1m and %gh, e.g. 30m or 2h", maxSpawnWindow.Hours())})
+		} else {
+			window = d
+		}
+	}
+	weightBy := strings.TrimSpace(query.Get("weight"))
+	if weightBy == "" {
+		weightBy = "spawn_chance"
+	}
+	weight, ok := spawnWeights[weightBy]
+	if !ok {
+		errs = append(errs, ValidationError{Field: "weight", Message: fmt.Sprintf("unknown weight %q, expected spawn_chance or avg_spawns", weightBy)})
+	}
+	limit, verr := intParam(query, "limit", defaultSpawnLimit, 1, maxSpawnLimit)
+	if verr != nil {
+		errs = append(errs, *verr)
+	}
+	if len(errs) > 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid spawn query", Details: errs})
+		return
+	}
+
+	minute := int(at)
+	span := int(window / time.Minute)
+	from, to := minute-span, minute+span
+	if 2*span >= minutesPerDay {
+		from, to = 0, minutesPerDay-1
+	}
+
+	db.rlockCtx(r.Context())
+	byID := make(map[int]*Pokemon, len(db.pokemons))
+	for i := range db.pokemons {
+		byID[db.pokemons[i].ID] = &db.pokemons[i]
+	}
+
+	spawns := []SpawnCandidate{}
+	var scores []float64 // গোল করার আগের score, probability এর জন্য
+	totalScore := 0.0
+	for _, e := range spawnIdx.between(wrapMinute(from), wrapMinute(to)) {
+		p := byID[e.id]
+		offset := clockOffset(minute, e.minute)
+		if p == nil || absInt(offset) > span {
+			continue
+		}
+		wt := weight(p)
+		score := wt * (1 - float64(absInt(offset))/float64(span+1))
+		totalScore += score
+		scores = append(scores, score)
+		spawns = append(spawns, SpawnCandidate{
+			ID:            p.ID,
+			Num:           p.Num,
+			Name:          p.Name,
+			SpawnTime:     formatClock(e.minute),
+			OffsetMinutes: offset,
+			Weight:        wt,
+			Score:         roundMetric(score),
+		})
+	}
+	db.RUnlock()
+
+	for i := range spawns {
+		if totalScore > 0 {
+			spawns[i].Probability = roundMetric(scores[i] / totalScore)
+		}
+	}
+	sort.SliceStable(spawns, func(i, j int) bool {
+		if spawns[i].Score != spawns[j].Score {
+			return spawns[i].Score > spawns[j].Score
+		}
+		if absInt(spawns[i].OffsetMinutes) != absInt(spawns[j].OffsetMinutes) {
+			return absInt(spawns[i].OffsetMinutes) < absInt(spawns[j].OffsetMinutes)
+		}
+		return spawns[i].ID < spawns[j].ID
+	})
+
+	count := len(spawns)
+	if len(spawns) > limit {
+		spawns = spawns[:limit]
+	}
+	respondJSON(w, http.StatusOK, SpawnWindowResponse{
+		At:            formatClock(minute),
+		WindowMinutes: span,
+		From:          formatClock(minute - span),
+		To:            formatClock(minute + span),
+		WeightBy:      weightBy,
+		Count:         count,
+		Spawns:        spawns,
+	})
+}
+
+// মধ্যরাতের আগে-পরে গেলে দিনের মিনিটে ফিরিয়ে আনা
+func wrapMinute(m int) int {
+	return (m%minutesPerDay + minutesPerDay) % minutesPerDay
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// GET /api/spawns/hourly
+func getSpawnsHourly(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	hours := make([]SpawnHour, 24)
+	for h := range hours {
+		hours[h].Hour = h
+		hours[h].From = formatClock(h * 60)
+		hours[h].To = formatClock(h*60 + 59)
+	}
+
+	db.rlockCtx(r.Context())
+	scheduled := 0
+	totalChance := 0.0
+	for i := range db.pokemons {
+		p := &db.pokemons[i]
+		m, ok := spawnIdx.minutes[p.ID]
+		if !ok {
+			continue
+		}
+		h := &hours[m/60]
+		h.Count++
+		h.SpawnChance += p.SpawnChance
+		h.AvgSpawns += p.AvgSpawns
+		scheduled++
+		totalChance += p.SpawnChance
+	}
+	total := len(db.pokemons)
+	db.RUnlock()
+
+	for i := range hours {
+		h := &hours[i]
+		h.SpawnChance = roundMetric(h.SpawnChance)
+		h.AvgSpawns = roundMetric(h.AvgSpawns)
+		if totalChance > 0 {
+			h.Share = roundMetric(h.SpawnChance / totalChance)
+		}
+	}
+
+	respondJSON(w, http.StatusOK, SpawnHourlyResponse{
+		Scheduled:   scheduled,
+		Unscheduled: total - scheduled,
+		Hours:       hours,
+	})
+}
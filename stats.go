@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ==================== STATISTICS ====================
+//
+// খালি ডেটাসেটেও প্যানিক বা শূন্য দিয়ে ভাগ হয় না: গড়/সর্বোচ্চ-সর্বনিম্ন null, তালিকা ও ম্যাপ খালি।
+
+const histogramBuckets = 10
+
+// GET /api/stats
+func getStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	stats := computeStats(db.pokemons)
+	db.RUnlock()
+
+	respondJSON(w, http.StatusOK, stats)
+}
+
+func computeStats(pokemons []Pokemon) StatsResponse {
+	stats := StatsResponse{
+		TotalPokemons:    len(pokemons),
+		ByType:           make(map[string]int),
+		ByTypePair:       make(map[string]int),
+		ByEvolutionStage: make(map[string]int),
+		HighestSpawn:     []Pokemon{},
+		LowestSpawn:      []Pokemon{},
+		LastUpdated:      time.Now().Format(time.RFC3339),
+	}
+
+	members := make([]*Pokemon, len(pokemons))
+	for i := range pokemons {
+		p := &pokemons[i]
+		members[i] = p
+
+		for _, t := range uniqueStrings(p.Type) {
+			stats.ByType[t]++
+		}
+		if pair := typePair(p.Type); pair != "" {
+			stats.ByTypePair[pair]++
+		}
+		stats.ByEvolutionStage[evolutionStage(p)]++
+	}
+
+	if len(pokemons) > 0 {
+		total := 0.0
+		highest, lowest := pokemons[0].SpawnChance, pokemons[0].SpawnChance
+		for i := range pokemons {
+			total += pokemons[i].SpawnChance
+			highest = math.Max(highest, pokemons[i].SpawnChance)
+			lowest = math.Min(lowest, pokemons[i].SpawnChance)
+		}
+		avg := roundMetric(total / float64(len(pokemons)))
+		stats.AvgSpawnChance = &avg
+
+		// সমান মানের সবগুলো, প্রথমটি নয়
+		for i := range pokemons {
+			if pokemons[i].SpawnChance == highest {
+				stats.HighestSpawn = append(stats.HighestSpawn, pokemons[i])
+			}
+			if pokemons[i].SpawnChance == lowest {
+				stats.LowestSpawn = append(stats.LowestSpawn, pokemons[i])
+			}
+		}
+	}
+
+	stats.Distributions = map[string]Distribution{
+		"spawn_chance": distributionOf(metricValues(members, "spawn_chance")),
+		"height":       distributionOf(metricValues(members, "height")),
+		"weight":       distributionOf(metricValues(members, "weight")),
+	}
+
+	return stats
+}
+
+// দ্বৈত টাইপের জোড়া, ক্রম নিরপেক্ষ ("Grass/Poison"); একক টাইপ হলে খালি
+func typePair(types []string) string {
+	types = uniqueStrings(types)
+	if len(types) != 2 {
+		return ""
+	}
+	pair := []string{types[0], types[1]}
+	sort.Strings(pair)
+	return strings.Join(pair, "/")
+}
+
+// আগের ইভোলিউশনের সংখ্যা থেকে ধাপ: basic, stage_1, stage_2 ...
+func evolutionStage(p *Pokemon) string {
+	if len(p.PrevEvolution) == 0 {
+		return "basic"
+	}
+	return fmt.Sprintf("stage_%d", len(p.PrevEvolution))
+}
+
+// সাজানো মান থেকে সারাংশ ও সমান প্রস্থের হিস্টোগ্রাম
+func distributionOf(sorted []float64) Distribution {
+	d := Distribution{Count: len(sorted), Histogram: []HistogramBucket{}}
+	if len(sorted) == 0 {
+		return d
+	}
+
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	mean := sum(sorted) / float64(len(sorted))
+	variance := 0.0
+	for _, v := range sorted {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(sorted))
+
+	value := func(v float64) *float64 {
+		v = roundMetric(v)
+		return &v
+	}
+	d.Min = value(lo)
+	d.Max = value(hi)
+	d.Mean = value(mean)
+	d.Median = value(percentile(sorted, 50))
+	d.P25 = value(percentile(sorted, 25))
+	d.P75 = value(percentile(sorted, 75))
+	d.StdDev = value(math.Sqrt(variance))
+
+	if lo == hi {
+		d.Histogram = append(d.Histogram, HistogramBucket{From: lo, To: hi, Count: len(sorted)})
+		return d
+	}
+
+	width := (hi - lo) / histogramBuckets
+	counts := make([]int, histogramBuckets)
+	for _, v := range sorted {
+		i := int((v - lo) / width)
+		if i >= histogramBuckets {
+			i = histogramBuckets - 1 // সর্বোচ্চ মান শেষ বাকেটে
+		}
+		counts[i]++
+	}
+	for i, n := range counts {
+		d.Histogram = append(d.Histogram, HistogramBucket{
+			From:  roundMetric(lo + width*float64(i)),
+			To:    roundMetric(lo + width*float64(i+1)),
+			Count: n,
+		})
+	}
+	return d
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ==================== AUTOCOMPLETE ====================
+//
+// নাম, নামের প্রতিটি শব্দ এবং num এর উপর প্রিফিক্স ট্রি।
+// সার্চ ইনডেক্সের মতোই db লকের অধীনে থাকে এবং indexes.go এর indexPokemon/unindexPokemon দিয়ে আপডেট হয়।
+
+const (
+	defaultSuggestLimit = 5
+	maxSuggestLimit     = 20
+)
+
+// কোন কী দিয়ে মিলেছে; ছোট মান আগে দেখানো হয়
+const (
+	matchName = iota
+	matchNum
+	matchWord
+)
+
+var matchKinds = []string{"name", "num", "word"}
+
+// প্রতিটি নোডে তার সাবট্রির সেরা maxSuggestLimit টি এন্ট্রি সাজানো অবস্থায় রাখা হয়,
+// তাই কোয়েরিতে শুধু প্রিফিক্স পর্যন্ত হাঁটতে হয় - খরচ ডেটাসেটের আকারের উপর নির্ভর করে না
+type trieNode struct {
+	children map[rune]*trieNode
+	entries  map[int]trieEntry // এই নোডে শেষ হওয়া কীগুলো
+	top      []trieEntry
+}
+
+type trieEntry struct {
+	id     int
+	kind   int
+	length int    // পুরো কী এর দৈর্ঘ্য; ছোট সম্পূর্ণ শব্দ আগে আসে
+	name   string // টাই ভাঙার জন্য, ছোট হাতের
+}
+
+func (a trieEntry) less(b trieEntry) bool {
+	if a.kind != b.kind {
+		return a.kind < b.kind
+	}
+	if a.length != b.length {
+		return a.length < b.length
+	}
+	if a.name != b.name {
+		return a.name < b.name
+	}
+	return a.id < b.id
+}
+
+// নাম ও num এক ট্রিতে, নামের ভেতরের শব্দ আলাদা ট্রিতে - শব্দের মিল শুধু বাকি জায়গা পূরণ করে
+type suggestTrie struct {
+	root  *trieNode
+	words *trieNode
+	keys  map[int][]trieKey // id -> কীগুলো, মুছে ফেলার জন্য
+	docs  map[int]Suggestion
+}
+
+type trieKey struct {
+	root *trieNode
+	key  string
+}
+
+var suggestIdx = newSuggestTrie()
+
+func newSuggestTrie() *suggestTrie {
+	return &suggestTrie{
+		root:  &trieNode{},
+		words: &trieNode{},
+		keys:  make(map[int][]trieKey),
+		docs:  make(map[int]Suggestion),
+	}
+}
+
+func (t *suggestTrie) add(p *Pokemon) {
+	t.docs[p.ID] = Suggestion{ID: p.ID, Num: p.Num, Name: p.Name}
+
+	t.insert(t.root, strings.ToLower(strings.TrimSpace(p.Name)), p.ID, matchName)
+	t.insert(t.root, p.Num, p.ID, matchNum)
+	// "025" কে "25" লিখেও পাওয়া যায়
+	if trimmed := strings.TrimLeft(p.Num, "0"); trimmed != p.Num {
+		t.insert(t.root, trimmed, p.ID, matchNum)
+	}
+	// "Mr. Mime" এর "mime" এর মতো নামের ভেতরের শব্দ
+	if words := tokenize(p.Name); len(words) > 1 {
+		for _, word := range words[1:] {
+			t.insert(t.words, word, p.ID, matchWord)
+		}
+	}
+}
+
+func (t *suggestTrie) insert(root *trieNode, key string, id, kind int) {
+	if key == "" {
+		return
+	}
+	entry := trieEntry{id: id, kind: kind, length: len([]rune(key)), name: strings.ToLower(t.docs[id].Name)}
+
+	node := root
+	node.offer(entry)
+	for _, r := range key {
+		if node.children == nil {
+			node.children = make(map[rune]*trieNode)
+		}
+		child := node.children[r]
+		if child == nil {
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+		node.offer(entry)
+	}
+	if node.entries == nil {
+		node.entries = make(map[int]trieEntry)
+	}
+	// একই কী দুইভাবে মিললে ভালোটা থাকে
+	if old, ok := node.entries[id]; !ok || entry.less(old) {
+		node.entries[id] = entry
+	}
+	t.keys[id] = append(t.keys[id], trieKey{root, key})
+}
+
+// top তালিকায় এন্ট্রি বসায়; একই id আগে থাকলে ভালো র‍্যাঙ্কটা থাকে
+func (n *trieNode) offer(e trieEntry) {
+	for i, old := range n.top {
+		if old.id == e.id {
+			if !e.less(old) {
+				return
+			}
+			n.top = append(n.top[:i], n.top[i+1:]...)
+			break
+		}
+	}
+	i := sort.Search(len(n.top), func(i int) bool { return e.less(n.top[i]) })
+	if i >= maxSuggestLimit {
+		return
+	}
+	n.top = append(n.top, trieEntry{})
+	copy(n.top[i+1:], n.top[i:])
+	n.top[i] = e
+	if len(n.top) > maxSuggestLimit {
+		n.top = n.top[:maxSuggestLimit]
+	}
+}
+
+// নিজের এন্ট্রি ও চাইল্ডদের top থেকে top নতুন করে বানায়
+func (n *trieNode) recompute() {
+	n.top = n.top[:0]
+	for _, e := range n.entries {
+		n.offer(e)
+	}
+	for _, child := range n.children {
+		for _, e := range child.top {
+			n.offer(e)
+		}
+	}
+}
+
+func (t *suggestTrie) remove(id int) {
+	for _, k := range t.keys[id] {
+		t.prune(k.root, []rune(k.key), id)
+	}
+	delete(t.keys, id)
+	delete(t.docs, id)
+}
+
+// কী এর শেষ নোড থেকে id সরায়, পথের top তালিকা নিচ থেকে উপরে আবার বানায়
+// এবং খালি হয়ে যাওয়া নোড কেটে ফেলে
+func (t *suggestTrie) prune(node *trieNode, key []rune, id int) bool {
+	if len(key) == 0 {
+		delete(node.entries, id)
+	} else if child := node.children[key[0]]; child != nil {
+		if t.prune(child, key[1:], id) {
+			delete(node.children, key[0])
+		}
+	}
+	node.recompute()
+	return len(node.entries) == 0 && len(node.children) == 0
+}
+
+func (t *suggestTrie) suggest(prefix string, limit int) []Suggestion {
+	prefix = strings.ToLower(prefix)
+	results := make([]Suggestion, 0, limit)
+	seen := make(map[int]bool)
+	results = t.collect(t.root, prefix, limit, seen, results)
+	return t.collect(t.words, prefix, limit, seen, results)
+}
+
+func (t *suggestTrie) collect(node *trieNode, prefix string, limit int, seen map[int]bool, results []Suggestion) []Suggestion {
+	for _, r := range prefix {
+		node = node.children[r]
+		if node == nil {
+			return results
+		}
+	}
+
+	for _, e := range node.top {
+		if len(results) == limit {
+			break
+		}
+		if seen[e.id] {
+			continue
+		}
+		seen[e.id] = true
+		s := t.docs[e.id]
+		s.Match = matchKinds[e.kind]
+		results = append(results, s)
+	}
+	return results
+}
+
+// GET /api/pokemons/suggest?q=cha&limit=5
+func suggestPokemons(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	invalid := func(field, message string) {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid suggest query",
+			Details: []ValidationError{{Field: field, Message: message}},
+		})
+	}
+
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		invalid("q", "is required")
+		return
+	}
+
+	limit := defaultSuggestLimit
+	if s := query.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxSuggestLimit {
+			invalid("limit", fmt.Sprintf("must be an integer between 1 and %d", maxSuggestLimit))
+			return
+		}
+		limit = n
+	}
+
+	db.rlockCtx(r.Context())
+	suggestions := suggestIdx.suggest(q, limit)
+	db.RUnlock()
+
+	respondJSON(w, http.StatusOK, SuggestResponse{Query: q, Suggestions: suggestions})
+}
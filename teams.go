@@ -0,0 +1,558 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==================== TEAMS ====================
+//
+// টিম হলো সর্বোচ্চ ছয়টি Pokémon ID এর তালিকা। টিমগুলো db তেই থাকে এবং একই লক ব্যবহার করে,
+// যাতে Pokémon মুছলে টিম থেকেও সরে যায়। teams.json থেকে লোড হয়ে প্রতিটি লেখার পর
+// সেই ফাইলেই সেভ হয়। Pokémon ID প্রতিবার লোডে নতুন করে দেওয়া হয়, তাই ফাইলে সদস্যরা
+// num দিয়ে থাকে এবং লোডের সময় বর্তমান ID তে বদলায়।
+
+const (
+	maxTeamSize           = 6
+	maxTeamSuggestLimit   = 20
+	defaultTeamSuggestion = 5
+	teamsFile             = "teams.json"
+)
+
+type Team struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Members   []int     `json:"members"` // Pokémon ID
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// ফাইলে টিমের রূপ: সদস্যরা Pokémon num
+type storedTeam struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Members   []string  `json:"members"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// ফাইল না থাকলে কিছু করে না; মুছে যাওয়া Pokémon এর num বাদ দেওয়া হয়
+func loadTeamsFromJSON(filename string) error {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored []storedTeam
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+
+	idByNum := make(map[string]int, len(db.pokemons))
+	for _, p := range db.pokemons {
+		idByNum[p.Num] = p.ID
+	}
+	for _, st := range stored {
+		t := Team{ID: st.ID, Name: st.Name, Members: make([]int, 0, len(st.Members)), CreatedAt: st.CreatedAt, UpdatedAt: st.UpdatedAt}
+		for _, num := range st.Members {
+			if id, ok := idByNum[num]; ok {
+				t.Members = append(t.Members, id)
+			}
+		}
+		db.teams = append(db.teams, t)
+		db.teamCounter = max(db.teamCounter, t.ID+1)
+	}
+	return nil
+}
+
+// সদস্যদের ID থেকে num এ বদলে ফাইলের রূপ (db লক ধরে রেখে)
+func storedTeams() []storedTeam {
+	numByID := make(map[int]string, len(db.pokemons))
+	for _, p := range db.pokemons {
+		numByID[p.ID] = p.Num
+	}
+	stored := make([]storedTeam, 0, len(db.teams))
+	for _, t := range db.teams {
+		st := storedTeam{ID: t.ID, Name: t.Name, Members: make([]string, 0, len(t.Members)), CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt}
+		for _, id := range t.Members {
+			if num, ok := numByID[id]; ok {
+				st.Members = append(st.Members, num)
+			}
+		}
+		stored = append(stored, st)
+	}
+	return stored
+}
+
+// টিমগুলো teamsFile এ সেভ (কলারকে অবশ্যই db লক ধরে রাখতে হবে)
+func saveTeams(ctx context.Context) {
+	if err := persistJSON(ctx, "teams", teamsFile, storedTeams()); err != nil {
+		log.Printf("Warning: Could not save teams: %v", err)
+	}
+}
+
+func pokemonIDs() map[int]bool {
+	ids := make(map[int]bool, len(db.pokemons))
+	for _, p := range db.pokemons {
+		ids[p.ID] = true
+	}
+	return ids
+}
+
+// কোনো টিমে pokemonID আছে কিনা, num বদলালে ফাইল আবার সেভ করতে (db লক ধরে রেখে)
+func inAnyTeam(pokemonID int) bool {
+	for _, t := range db.teams {
+		if slices.Contains(t.Members, pokemonID) {
+			return true
+		}
+	}
+	return false
+}
+
+// মুছে ফেলা Pokémon টিমগুলো থেকে সরায়; কতগুলো টিম বদলেছে তা ফেরত দেয় (db.Lock ধরে রেখে)
+func removeFromTeams(pokemonID int) int {
+	changed := 0
+	for i := range db.teams {
+		t := &db.teams[i]
+		kept := make([]int, 0, len(t.Members))
+		for _, id := range t.Members {
+			if id != pokemonID {
+				kept = append(kept, id)
+			}
+		}
+		if len(kept) != len(t.Members) {
+			t.Members = kept
+			t.UpdatedAt = time.Now()
+			changed++
+		}
+	}
+	return changed
+}
+
+// সব Pokémon মুছলে ID আবার 1 থেকে শুরু হয়, তাই পুরনো ID গুলো রাখা যায় না
+func clearTeamMembers() int {
+	changed := 0
+	for i := range db.teams {
+		if len(db.teams[i].Members) > 0 {
+			db.teams[i].Members = []int{}
+			db.teams[i].UpdatedAt = time.Now()
+			changed++
+		}
+	}
+	return changed
+}
+
+// বডি ডিকোড ও আকার যাচাই (লক ছাড়া); ত্রুটি হলে রেসপন্স পাঠিয়ে false ফেরত দেয়
+func decodeTeam(w http.ResponseWriter, r *http.Request, team *Team) bool {
+	if err := decodeJSON(r, team); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return false
+	}
+
+	var errs []ValidationError
+	team.Name = strings.TrimSpace(team.Name)
+	if team.Name == "" {
+		errs = append(errs, ValidationError{Field: "name", Message: "is required"})
+	}
+	if team.Members == nil {
+		team.Members = []int{}
+	}
+	if len(team.Members) > maxTeamSize {
+		errs = append(errs, ValidationError{Field: "members", Message: fmt.Sprintf("a team has at most %d members, got %d", maxTeamSize, len(team.Members))})
+	}
+	seen := make(map[int]int, len(team.Members))
+	for i, id := range team.Members {
+		if j, ok := seen[id]; ok {
+			errs = append(errs, ValidationError{Field: fmt.Sprintf("members[%d]", i), Message: fmt.Sprintf("Pokémon %d is already in the team at members[%d]", id, j)})
+			continue
+		}
+		seen[id] = i
+	}
+	if len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return false
+	}
+	return true
+}
+
+// সদস্যরা বিদ্যমান কিনা; ত্রুটি হলে রেসপন্স পাঠিয়ে false ফেরত দেয় (db লক ধরে রেখে)
+func checkTeamMembers(w http.ResponseWriter, members []int) bool {
+	var errs []ValidationError
+	exists := pokemonIDs()
+	for i, id := range members {
+		if !exists[id] {
+			errs = append(errs, ValidationError{Field: fmt.Sprintf("members[%d]", i), Message: fmt.Sprintf("no Pokémon with id %d", id)})
+		}
+	}
+	if len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return false
+	}
+	return true
+}
+
+func teamIndex(id int) int {
+	for i, t := range db.teams {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func teamIDFromRequest(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// GET /api/teams
+func getTeams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	teams := append([]Team{}, db.teams...)
+	respondJSON(w, http.StatusOK, TeamListResponse{Count: len(teams), Teams: teams})
+}
+
+// POST /api/teams
+func createTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var team Team
+	if !decodeTeam(w, r, &team) {
+		return
+	}
+
+	db.lockCtx(r.Context())
+	defer db.Unlock()
+
+	if !checkTeamMembers(w, team.Members) {
+		return
+	}
+
+	team.ID = db.teamCounter
+	db.teamCounter++
+	team.CreatedAt = time.Now()
+	team.UpdatedAt = time.Now()
+	db.teams = append(db.teams, team)
+	saveTeams(r.Context())
+
+	respondJSON(w, http.StatusCreated, TeamResponse{Message: "Team created successfully", Team: team})
+}
+
+// GET /api/teams/{id}
+func getTeamByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, ok := teamIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	if i := teamIndex(id); i >= 0 {
+		respondJSON(w, http.StatusOK, db.teams[i])
+		return
+	}
+	respondError(w, http.StatusNotFound, "Team not found")
+}
+
+// PUT /api/teams/{id}
+func updateTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, ok := teamIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var team Team
+	if !decodeTeam(w, r, &team) {
+		return
+	}
+
+	db.lockCtx(r.Context())
+	defer db.Unlock()
+
+	i := teamIndex(id)
+	if i < 0 {
+		respondError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+	if !checkTeamMembers(w, team.Members) {
+		return
+	}
+	team.ID = id
+	team.CreatedAt = db.teams[i].CreatedAt
+	team.UpdatedAt = time.Now()
+	db.teams[i] = team
+	saveTeams(r.Context())
+
+	respondJSON(w, http.StatusOK, TeamResponse{Message: "Team updated successfully", Team: team})
+}
+
+// DELETE /api/teams/{id}
+func deleteTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, ok := teamIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	db.lockCtx(r.Context())
+	defer db.Unlock()
+
+	i := teamIndex(id)
+	if i < 0 {
+		respondError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+	db.teams = append(db.teams[:i], db.teams[i+1:]...)
+	saveTeams(r.Context())
+
+	respondJSON(w, http.StatusOK, DeleteResponse{Message: "Team deleted successfully", ID: strconv.Itoa(id)})
+}
+
+// ==================== TEAM ANALYSIS ====================
+//
+// প্রতিরক্ষা: সদস্যের সংরক্ষিত weaknesses (না থাকলে টাইপ থেকে হিসাব), প্রতিরোধ ও ইমিউনিটি টাইপ চার্ট থেকে।
+// আক্রমণ: প্রতিটি সদস্যের নিজের টাইপের মুভ ধরে কোন টাইপকে super-effective আঘাত করা যায়।
+
+// p কোন আক্রমণ টাইপে দুর্বল, প্রতিরোধী বা ইমিউন
+func memberDefense(p *Pokemon) (weak, resist, immune map[string]bool) {
+	weak, resist, immune = make(map[string]bool), make(map[string]bool), make(map[string]bool)
+	computed := typeDefense(p.Type)
+	for _, s := range p.Weaknesses {
+		if t, ok := canonicalType(s); ok {
+			weak[t] = true
+		}
+	}
+	if len(weak) == 0 {
+		for _, t := range computed.Weaknesses {
+			weak[t] = true
+		}
+	}
+	for _, t := range computed.Resistances {
+		if !weak[t] {
+			resist[t] = true
+		}
+	}
+	for _, t := range computed.Immunities {
+		if !weak[t] {
+			immune[t] = true
+		}
+	}
+	return weak, resist, immune
+}
+
+// p এর টাইপের মুভ কোন ডিফেন্ডার টাইপের বিরুদ্ধে super-effective
+func hitsSuperEffective(p *Pokemon) map[string]bool {
+	hits := make(map[string]bool)
+	for _, attacker := range pokemonTypes {
+		if !hasType(attacker)(p) {
+			continue
+		}
+		for _, defender := range typeOffense(attacker).SuperEffective {
+			hits[defender] = true
+		}
+	}
+	return hits
+}
+
+// দুই বা তার বেশি সদস্য দুর্বল এবং দুর্বলের সংখ্যা প্রতিরোধী+ইমিউনের চেয়ে বেশি
+func sharedWeaknesses(members []*Pokemon) []TeamWeakness {
+	shared := []TeamWeakness{}
+	defenses := make([][3]map[string]bool, len(members))
+	for i, p := range members {
+		weak, resist, immune := memberDefense(p)
+		defenses[i] = [3]map[string]bool{weak, resist, immune}
+	}
+	for _, t := range pokemonTypes {
+		tw := TeamWeakness{Type: t, Weak: []string{}, Resists: []string{}, Immune: []string{}}
+		for i, p := range members {
+			switch {
+			case defenses[i][0][t]:
+				tw.Weak = append(tw.Weak, p.Name)
+			case defenses[i][1][t]:
+				tw.Resists = append(tw.Resists, p.Name)
+			case defenses[i][2][t]:
+				tw.Immune = append(tw.Immune, p.Name)
+			}
+		}
+		if len(tw.Weak) >= 2 && len(tw.Weak) > len(tw.Resists)+len(tw.Immune) {
+			shared = append(shared, tw)
+		}
+	}
+	sort.SliceStable(shared, func(i, j int) bool { return len(shared[i].Weak) > len(shared[j].Weak) })
+	return shared
+}
+
+// কোনো সদস্য super-effective আঘাত করতে পারে না এমন ডিফেন্ডার টাইপ, চার্টের ক্রমে
+func uncoveredTypes(members []*Pokemon) []string {
+	covered := make(map[string]bool)
+	for _, p := range members {
+		for t := range hitsSuperEffective(p) {
+			covered[t] = true
+		}
+	}
+	uncovered := []string{}
+	for _, t := range pokemonTypes {
+		if !covered[t] {
+			uncovered = append(uncovered, t)
+		}
+	}
+	return uncovered
+}
+
+// স্কোর = ঢাকা না থাকা টাইপ যতগুলো আঘাত করে + ভাগ করা দুর্বলতা যতগুলো প্রতিরোধ করে - যতগুলোতে নিজেও দুর্বল
+func suggestTeamAdditions(members []*Pokemon, shared []TeamWeakness, uncovered []string, limit int) []TeamSuggestion {
+	suggestions := []TeamSuggestion{}
+	if len(members) >= maxTeamSize {
+		return suggestions
+	}
+
+	inTeam := make(map[int]bool, len(members))
+	for _, p := range members {
+		inTeam[p.ID] = true
+	}
+
+	for i := range db.pokemons {
+		p := &db.pokemons[i]
+		if inTeam[p.ID] {
+			continue
+		}
+		s := TeamSuggestion{ID: p.ID, Num: p.Num, Name: p.Name, Type: p.Type, Covers: []string{}, Resists: []string{}, WeakTo: []string{}}
+
+		hits := hitsSuperEffective(p)
+		for _, t := range uncovered {
+			if hits[t] {
+				s.Covers = append(s.Covers, t)
+			}
+		}
+		weak, resist, immune := memberDefense(p)
+		for _, tw := range shared {
+			switch {
+			case weak[tw.Type]:
+				s.WeakTo = append(s.WeakTo, tw.Type)
+			case resist[tw.Type] || immune[tw.Type]:
+				s.Resists = append(s.Resists, tw.Type)
+			}
+		}
+
+		s.Score = len(s.Covers) + len(s.Resists) - len(s.WeakTo)
+		if s.Score > 0 {
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].ID < suggestions[j].ID
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// GET /api/teams/{id}/analysis
+func getTeamAnalysis(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, ok := teamIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	limit := defaultTeamSuggestion
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxTeamSuggestLimit {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid team analysis query",
+				Details: []ValidationError{{Field: "limit", Message: fmt.Sprintf("must be an integer between 1 and %d", maxTeamSuggestLimit)}},
+			})
+			return
+		}
+		limit = n
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	i := teamIndex(id)
+	if i < 0 {
+		respondError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+	team := db.teams[i]
+
+	byID := make(map[int]*Pokemon, len(db.pokemons))
+	for j := range db.pokemons {
+		byID[db.pokemons[j].ID] = &db.pokemons[j]
+	}
+	members := make([]*Pokemon, 0, len(team.Members))
+	sides := make([]MatchupSide, 0, len(team.Members))
+	for _, mid := range team.Members {
+		if p := byID[mid]; p != nil {
+			members = append(members, p)
+			sides = append(sides, matchupSide(p))
+		}
+	}
+
+	shared := sharedWeaknesses(members)
+	uncovered := uncoveredTypes(members)
+
+	respondJSON(w, http.StatusOK, TeamAnalysisResponse{
+		Team:             team,
+		Members:          sides,
+		SharedWeaknesses: shared,
+		UncoveredTypes:   uncovered,
+		Suggestions:      suggestTeamAdditions(members, shared, uncovered, limit),
+	})
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateTeamValidation(t *testing.T) {
+	mux, _ := newServeMux()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/teams", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := post(`{"name":"Echo","members":[1,1,1]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("duplicate members: status %d, want 400", rec.Code)
+	}
+	var errBody ErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &errBody)
+	if len(errBody.Details) != 2 || errBody.Details[0].Field != "members[1]" {
+		t.Errorf("duplicate members: details %+v, want members[1] and members[2]", errBody.Details)
+	}
+
+	if rec := post(`{"name":"Ghosts","members":[1,9999]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown member: status %d, want 400", rec.Code)
+	}
+
+	rec = post(`{"name":"Starters","members":[1,4]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("valid team: status %d, body %s", rec.Code, rec.Body)
+	}
+	var created TeamResponse
+	json.Unmarshal(rec.Body.Bytes(), &created)
+
+	db.Lock()
+	defer db.Unlock()
+	if i := teamIndex(created.Team.ID); i >= 0 {
+		db.teams = append(db.teams[:i], db.teams[i+1:]...)
+	}
+}
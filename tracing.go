@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("pokemon-api")
+
+// ট্রেসিং সেটআপ
+//
+// OTEL_TRACES_EXPORTER এনভায়রনমেন্ট ভেরিয়েবল দিয়ে এক্সপোর্টার বাছাই করা যায়:
+//
+//	none    - ট্রেসিং বন্ধ (ডিফল্ট), তবে traceparent প্রোপাগেশন চালু থাকে
+//	otlp    - OTLP/HTTP, OTEL_EXPORTER_OTLP_ENDPOINT থেকে এন্ডপয়েন্ট নেয়
+//	console - stdout এ JSON স্প্যান
+//	file    - OTEL_TRACES_FILE ফাইলে JSON স্প্যান (ডিফল্ট traces.json)
+func setupTracing(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	noop := func(context.Context) error { return nil }
+
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+
+	switch name := strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")); name {
+	case "", "none":
+		return noop, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "console", "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "file":
+		filename := os.Getenv("OTEL_TRACES_FILE")
+		if filename == "" {
+			filename = "traces.json"
+		}
+		var f *os.File
+		f, err = os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return noop, err
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return noop, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q", name)
+	}
+	if err != nil {
+		return noop, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName("pokemon-api"),
+			semconv.ServiceVersion(getBuildInfo().Version),
+		),
+	)
+	if err != nil {
+		return noop, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	// গ্লোবাল ডেলিগেট শুধু প্রথম প্রোভাইডারে বাঁধা থাকে, তাই প্যাকেজের tracer সরাসরি নতুনটি থেকে
+	tracer = tp.Tracer("pokemon-api")
+	log.Printf("Tracing enabled (%s exporter)", os.Getenv("OTEL_TRACES_EXPORTER"))
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// রাউট অনুযায়ী সার্ভার স্প্যান, traceparent হেডার থেকে প্যারেন্ট নেয়
+func traced(route string, next http.HandlerFunc) http.HandlerFunc {
+	return otelhttp.NewHandler(next, route).ServeHTTP
+}
+
+// রিকোয়েস্ট বডি ডিকোড, আলাদা স্প্যানে
+func decodeJSON(r *http.Request, v interface{}) error {
+	_, span := tracer.Start(r.Context(), "json.decode")
+	defer span.End()
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		return err
+	}
+	return nil
+}
+
+// লক পাওয়া পর্যন্ত অপেক্ষার সময় স্প্যান হিসেবে রেকর্ড করে
+func (d *PokemonDB) lockCtx(ctx context.Context) {
+	_, span := tracer.Start(ctx, "PokemonDB.Lock")
+	d.Lock()
+	span.End()
+}
+
+func (d *PokemonDB) rlockCtx(ctx context.Context) {
+	_, span := tracer.Start(ctx, "PokemonDB.RLock")
+	d.RLock()
+	span.End()
+}
+
+// store এর v কে JSON ফাইলে লেখে, স্প্যান ও persist মেট্রিক সহ
+func persistJSON(ctx context.Context, store, path string, v interface{}) (err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("persist.store", store),
+		attribute.String("file.name", path),
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		attrs = append(attrs, attribute.Int("record.count", rv.Len()))
+	}
+	_, span := tracer.Start(ctx, "persistJSON", trace.WithAttributes(attrs...))
+	start := time.Now()
+	defer func() {
+		persistDuration.WithLabelValues(store).Observe(time.Since(start).Seconds())
+		recordPersistResult(store, err)
+		if err != nil {
+			persistFailuresTotal.WithLabelValues(store).Inc()
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
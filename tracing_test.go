@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// stdouttrace এক্সপোর্টারের JSON থেকে যেটুকু দরকার
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		TraceID string
+		SpanID  string
+		Remote  bool
+	}
+}
+
+func TestTracingSpansContinueIncomingTrace(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv("OTEL_TRACES_EXPORTER", "file")
+	t.Setenv("OTEL_TRACES_FILE", file)
+
+	shutdown, err := setupTracing(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux, _ := newServeMux()
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+	req := httptest.NewRequest(http.MethodPost, "/api/pokemons", strings.NewReader(`{"num":"905","name":"Traced"}`))
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST: status %d, body %s", rec.Code, rec.Body)
+	}
+	var created PokemonResponse
+	json.Unmarshal(rec.Body.Bytes(), &created)
+
+	// শাটডাউনে ব্যাচ এক্সপোর্টার ফ্লাশ হয়
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	spans := make(map[string]exportedSpan)
+	dec := json.NewDecoder(f)
+	for {
+		var s exportedSpan
+		if err := dec.Decode(&s); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		spans[s.Name] = s
+	}
+
+	const serverSpan = "POST /api/pokemons"
+	server, ok := spans[serverSpan]
+	if !ok {
+		t.Fatalf("no server span %q, got %v", serverSpan, keys(spans))
+	}
+	if server.Parent.SpanID != parentID || !server.Parent.Remote {
+		t.Errorf("server span parent %+v, want remote parent %s from traceparent", server.Parent, parentID)
+	}
+	for _, name := range []string{serverSpan, "json.decode", "PokemonDB.Lock", "persistJSON"} {
+		s, ok := spans[name]
+		if !ok {
+			t.Errorf("missing span %q, got %v", name, keys(spans))
+			continue
+		}
+		if s.SpanContext.TraceID != traceID {
+			t.Errorf("span %q is in trace %s, want %s", name, s.SpanContext.TraceID, traceID)
+		}
+		if name != serverSpan && s.Parent.SpanID != server.SpanContext.SpanID {
+			t.Errorf("span %q parent %s, want the server span %s", name, s.Parent.SpanID, server.SpanContext.SpanID)
+		}
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	for i := range db.pokemons {
+		if db.pokemons[i].ID == created.Pokemon.ID {
+			db.pokemons = append(db.pokemons[:i], db.pokemons[i+1:]...)
+			unindexPokemon(created.Pokemon.ID)
+			break
+		}
+	}
+}
+
+func keys(spans map[string]exportedSpan) []string {
+	var names []string
+	for name := range spans {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==================== TYPE CHART ====================
+//
+// ১৮টি টাইপের আক্রমণ-প্রতিরক্ষা গুণক। টেবিলে শুধু 1 নয় এমন মান থাকে।
+// দ্বৈত টাইপের প্রতিরক্ষা গুণক দুই টাইপের গুণফল (যেমন Grass/Poison এর বিরুদ্ধে Fire = 2 × 1)।
+
+var pokemonTypes = []string{
+	"Normal", "Fire", "Water", "Electric", "Grass", "Ice", "Fighting", "Poison", "Ground",
+	"Flying", "Psychic", "Bug", "Rock", "Ghost", "Dragon", "Dark", "Steel", "Fairy",
+}
+
+// attacker -> defender -> গুণক
+var typeChart = map[string]map[string]float64{
+	"Normal":   {"Rock": 0.5, "Ghost": 0, "Steel": 0.5},
+	"Fire":     {"Fire": 0.5, "Water": 0.5, "Grass": 2, "Ice": 2, "Bug": 2, "Rock": 0.5, "Dragon": 0.5, "Steel": 2},
+	"Water":    {"Fire": 2, "Water": 0.5, "Grass": 0.5, "Ground": 2, "Rock": 2, "Dragon": 0.5},
+	"Electric": {"Water": 2, "Electric": 0.5, "Grass": 0.5, "Ground": 0, "Flying": 2, "Dragon": 0.5},
+	"Grass":    {"Fire": 0.5, "Water": 2, "Grass": 0.5, "Poison": 0.5, "Ground": 2, "Flying": 0.5, "Bug": 0.5, "Rock": 2, "Dragon": 0.5, "Steel": 0.5},
+	"Ice":      {"Fire": 0.5, "Water": 0.5, "Grass": 2, "Ice": 0.5, "Ground": 2, "Flying": 2, "Dragon": 2, "Steel": 0.5},
+	"Fighting": {"Normal": 2, "Ice": 2, "Poison": 0.5, "Flying": 0.5, "Psychic": 0.5, "Bug": 0.5, "Rock": 2, "Ghost": 0, "Dark": 2, "Steel": 2, "Fairy": 0.5},
+	"Poison":   {"Grass": 2, "Poison": 0.5, "Ground": 0.5, "Rock": 0.5, "Ghost": 0.5, "Steel": 0, "Fairy": 2},
+	"Ground":   {"Fire": 2, "Electric": 2, "Grass": 0.5, "Poison": 2, "Flying": 0, "Bug": 0.5, "Rock": 2, "Steel": 2},
+	"Flying":   {"Electric": 0.5, "Grass": 2, "Fighting": 2, "Bug": 2, "Rock": 0.5, "Steel": 0.5},
+	"Psychic":  {"Fighting": 2, "Poison": 2, "Psychic": 0.5, "Dark": 0, "Steel": 0.5},
+	"Bug":      {"Fire": 0.5, "Grass": 2, "Fighting": 0.5, "Poison": 0.5, "Flying": 0.5, "Psychic": 2, "Ghost": 0.5, "Dark": 2, "Steel": 0.5, "Fairy": 0.5},
+	"Rock":     {"Fire": 2, "Ice": 2, "Fighting": 0.5, "Ground": 0.5, "Flying": 2, "Bug": 2, "Steel": 0.5},
+	"Ghost":    {"Normal": 0, "Psychic": 2, "Ghost": 2, "Dark": 0.5},
+	"Dragon":   {"Dragon": 2, "Steel": 0.5, "Fairy": 0},
+	"Dark":     {"Fighting": 0.5, "Psychic": 2, "Ghost": 2, "Dark": 0.5, "Fairy": 0.5},
+	"Steel":    {"Fire": 0.5, "Water": 0.5, "Electric": 0.5, "Ice": 2, "Rock": 2, "Steel": 0.5, "Fairy": 2},
+	"Fairy":    {"Fire": 0.5, "Fighting": 2, "Poison": 0.5, "Dragon": 2, "Dark": 2, "Steel": 0.5},
+}
+
+// "fire" -> "Fire"; চেনা টাইপ না হলে false
+func canonicalType(name string) (string, bool) {
+	for _, t := range pokemonTypes {
+		if strings.EqualFold(t, strings.TrimSpace(name)) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+func effectiveness(attacker, defender string) float64 {
+	if m, ok := typeChart[attacker][defender]; ok {
+		return m
+	}
+	return 1
+}
+
+// এক বা দুই টাইপের ডিফেন্ডারের বিরুদ্ধে আক্রমণ টাইপের গুণক; অচেনা টাইপ উপেক্ষা করা হয়
+func defenseMultiplier(attacker string, defender []string) float64 {
+	m := 1.0
+	for _, t := range defender {
+		if t, ok := canonicalType(t); ok {
+			m *= effectiveness(attacker, t)
+		}
+	}
+	return m
+}
+
+// ডিফেন্ডারের টাইপ থেকে দুর্বলতা, প্রতিরোধ ও ইমিউনিটি, চার্টের ক্রমে
+func typeDefense(types []string) TypeDefense {
+	d := TypeDefense{
+		Weaknesses:  []string{},
+		Resistances: []string{},
+		Immunities:  []string{},
+		Multipliers: make(map[string]float64),
+	}
+	for _, attacker := range pokemonTypes {
+		m := defenseMultiplier(attacker, types)
+		switch {
+		case m == 0:
+			d.Immunities = append(d.Immunities, attacker)
+		case m > 1:
+			d.Weaknesses = append(d.Weaknesses, attacker)
+		case m < 1:
+			d.Resistances = append(d.Resistances, attacker)
+		}
+		if m != 1 {
+			d.Multipliers[attacker] = m
+		}
+	}
+	return d
+}
+
+func typeOffense(attacker string) TypeOffense {
+	o := TypeOffense{SuperEffective: []string{}, NotVeryEffective: []string{}, NoEffect: []string{}}
+	for _, defender := range pokemonTypes {
+		switch m := effectiveness(attacker, defender); {
+		case m == 0:
+			o.NoEffect = append(o.NoEffect, defender)
+		case m > 1:
+			o.SuperEffective = append(o.SuperEffective, defender)
+		case m < 1:
+			o.NotVeryEffective = append(o.NotVeryEffective, defender)
+		}
+	}
+	return o
+}
+
+// db লক ধরে রেখে কল করতে হবে
+func typeInfo(name string) TypeInfo {
+	count := 0
+	for i := range db.pokemons {
+		if hasType(name)(&db.pokemons[i]) {
+			count++
+		}
+	}
+	return TypeInfo{
+		Name:         name,
+		Offense:      typeOffense(name),
+		Defense:      typeDefense([]string{name}),
+		PokemonCount: count,
+	}
+}
+
+// GET /api/types
+func getTypes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	types := make([]TypeInfo, 0, len(pokemonTypes))
+	for _, t := range pokemonTypes {
+		types = append(types, typeInfo(t))
+	}
+	respondJSON(w, http.StatusOK, TypeListResponse{Count: len(types), Types: types})
+}
+
+// GET /api/types/{type}
+func getType(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	name, ok := canonicalType(r.PathValue("type"))
+	if !ok {
+		respondError(w, http.StatusNotFound, "Unknown type")
+		return
+	}
+
+	attacking := make(map[string]float64, len(pokemonTypes))
+	defending := make(map[string]float64, len(pokemonTypes))
+	for _, t := range pokemonTypes {
+		attacking[t] = effectiveness(name, t)
+		defending[t] = effectiveness(t, name)
+	}
+
+	db.rlockCtx(r.Context())
+	info := typeInfo(name)
+	db.RUnlock()
+
+	respondJSON(w, http.StatusOK, TypeDetailResponse{Type: info, Attacking: attacking, Defending: defending})
+}
+
+// GET /api/pokemons/{id}/type-defense
+func getPokemonTypeDefense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	db.rlockCtx(r.Context())
+	defer db.RUnlock()
+
+	for i := range db.pokemons {
+		p := &db.pokemons[i]
+		if p.ID != id {
+			continue
+		}
+		response := PokemonTypeDefenseResponse{
+			ID:               p.ID,
+			Num:              p.Num,
+			Name:             p.Name,
+			Type:             p.Type,
+			StoredWeaknesses: p.Weaknesses,
+			Defense:          typeDefense(p.Type),
+		}
+		for _, t := range p.Type {
+			if _, ok := canonicalType(t); !ok {
+				response.UnknownTypes = append(response.UnknownTypes, t)
+			}
+		}
+		if response.Type == nil {
+			response.Type = []string{}
+		}
+		if response.StoredWeaknesses == nil {
+			response.StoredWeaknesses = []string{}
+		}
+		respondJSON(w, http.StatusOK, response)
+		return
+	}
+	respondError(w, http.StatusNotFound, "Pokemon not found")
+}
+
+// ==================== WEAKNESS AUDIT ====================
+
+// সংরক্ষিত weaknesses টাইপ থেকে হিসাব করা দুর্বলতার সাথে না মিললে বিবরণ, মিললে nil
+func checkWeaknesses(p *Pokemon) *WeaknessMismatch {
+	if len(p.Type) == 0 {
+		return nil
+	}
+
+	m := WeaknessMismatch{ID: p.ID, Num: p.Num, Name: p.Name, Type: p.Type, Stored: p.Weaknesses}
+	for _, t := range p.Type {
+		if _, ok := canonicalType(t); !ok {
+			m.UnknownTypes = append(m.UnknownTypes, t)
+		}
+	}
+	m.Computed = typeDefense(p.Type).Weaknesses
+
+	stored := make(map[string]bool)
+	for _, s := range p.Weaknesses {
+		if t, ok := canonicalType(s); ok {
+			stored[t] = true
+		} else {
+			m.Extra = append(m.Extra, s)
+		}
+	}
+	for _, t := range m.Computed {
+		if !stored[t] {
+			m.Missing = append(m.Missing, t)
+		}
+		delete(stored, t)
+	}
+	for _, t := range pokemonTypes {
+		if stored[t] {
+			m.Extra = append(m.Extra, t)
+		}
+	}
+
+	if len(m.Missing) == 0 && len(m.Extra) == 0 && len(m.UnknownTypes) == 0 {
+		return nil
+	}
+	return &m
+}
+
+// GET দিয়ে শুধু অমিলগুলো দেখায়, POST দিয়ে weaknesses টাইপ চার্ট অনুযায়ী ঠিক করে দেয়।
+// অচেনা টাইপের রেকর্ড ঠিক করা হয় না।
+func auditWeaknesses(w http.ResponseWriter, r *http.Request) {
+	fix := r.Method == http.MethodPost
+	if r.Method != http.MethodGet && !fix {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if fix {
+		db.lockCtx(r.Context())
+		defer db.Unlock()
+	} else {
+		db.rlockCtx(r.Context())
+		defer db.RUnlock()
+	}
+
+	response := WeaknessAuditResponse{Records: []WeaknessMismatch{}}
+	for i := range db.pokemons {
+		p := &db.pokemons[i]
+		if len(p.Type) > 0 {
+			response.Checked++
+		}
+		m := checkWeaknesses(p)
+		if m == nil {
+			continue
+		}
+		response.Mismatched++
+
+		if fix && len(m.UnknownTypes) == 0 {
+			p.Weaknesses = slices.Clone(m.Computed)
+			p.UpdatedAt = time.Now()
+			indexPokemon(p)
+			m.Fixed = true
+			response.Fixed++
+		}
+		response.Records = append(response.Records, *m)
+	}
+
+	if response.Fixed > 0 {
+		if err := saveToJSONCtx(r.Context(), "pokemon_backup.json"); err != nil {
+			log.Printf("Warning: Could not save backup: %v", err)
+		}
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
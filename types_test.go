@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestTypeDefenseDualType(t *testing.T) {
+	d := typeDefense([]string{"Fire", "Flying"})
+	for attacker, want := range map[string]float64{"Rock": 4, "Water": 2, "Grass": 0.25, "Fire": 0.5, "Ground": 0} {
+		if got := d.Multipliers[attacker]; got != want {
+			t.Errorf("%s vs Fire/Flying: %g, want %g", attacker, got, want)
+		}
+	}
+	if !slices.Equal(d.Immunities, []string{"Ground"}) {
+		t.Errorf("immunities %v, want [Ground]", d.Immunities)
+	}
+	// Grass/Poison এর বিরুদ্ধে Grass: 0.5 × 0.5, Fighting: 0.5 × 0.5, Fire: 2 × 1
+	d = typeDefense([]string{"Grass", "Poison"})
+	if d.Multipliers["Fire"] != 2 || d.Multipliers["Grass"] != 0.25 {
+		t.Errorf("Grass/Poison multipliers %v", d.Multipliers)
+	}
+}
+
+func TestPokemonTypeDefenseEndpoint(t *testing.T) {
+	mux, _ := newServeMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/4/type-defense", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body)
+	}
+	var body PokemonTypeDefenseResponse
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if body.Name != "Charmander" || !slices.Equal(body.Defense.Weaknesses, []string{"Water", "Ground", "Rock"}) || len(body.Defense.Resistances) == 0 {
+		t.Errorf("got %+v", body)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/9999/type-defense", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown id: status %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ==================== UNITS ====================
+//
+// height ও weight মূল স্ট্রিং হিসেবেই রাখা ও পাঠানো হয়, লেখার সময় পার্স করে
+// height_m ও weight_kg সেট হয় - ফিল্টার, সর্ট ও অ্যাগ্রিগেশন এগুলোই ব্যবহার করে।
+
+const (
+	metersPerInch = 0.0254
+	kgPerPound    = 0.45359237
+)
+
+// স্কিমার প্যাটার্ন; "0.71 m", "71 cm", "2 ft 4 in", "2'4\"", "28 in" / "6.9 kg", "15.2 lb"
+const (
+	heightPattern = `^[0-9]+(\.[0-9]+)? ?(m|cm|in|")$|^[0-9]+(\.[0-9]+)? ?(ft|')( ?[0-9]+(\.[0-9]+)? ?(in|"))?$`
+	weightPattern = `^[0-9]+(\.[0-9]+)? ?(kg|lbs?)$`
+)
+
+var (
+	metricLength   = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?) ?(m|cm|in|")$`)
+	imperialLength = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?) ?(?:ft|')(?: ?([0-9]+(?:\.[0-9]+)?) ?(?:in|"))?$`)
+	massPattern    = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?) ?(kg|lbs?)$`)
+)
+
+// উচ্চতা মিটারে
+func parseHeight(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if m := metricLength.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.ParseFloat(m[1], 64)
+		switch m[2] {
+		case "cm":
+			v /= 100
+		case "in", `"`:
+			v *= metersPerInch
+		}
+		return roundUnit(v), nil
+	}
+	if m := imperialLength.FindStringSubmatch(s); m != nil {
+		feet, _ := strconv.ParseFloat(m[1], 64)
+		inches := 0.0
+		if m[2] != "" {
+			inches, _ = strconv.ParseFloat(m[2], 64)
+		}
+		return roundUnit((feet*12 + inches) * metersPerInch), nil
+	}
+	return 0, fmt.Errorf("cannot parse height %q, expected a number with m, cm, ft/in or in", s)
+}
+
+// ওজন কিলোগ্রামে
+func parseWeight(s string) (float64, error) {
+	m := massPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("cannot parse weight %q, expected a number with kg or lb", s)
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	if m[2] != "kg" {
+		v *= kgPerPound
+	}
+	return roundUnit(v), nil
+}
+
+func roundUnit(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+// স্ট্রিং থেকে height_m/weight_kg; ক্লায়েন্টের পাঠানো মান উপেক্ষা করে প্রতিবার নতুন করে হিসাব হয়
+func normalizeMeasures(p *Pokemon) {
+	p.HeightM, p.WeightKg = nil, nil
+	if v, err := parseHeight(p.Height); err == nil {
+		p.HeightM = &v
+	}
+	if v, err := parseWeight(p.Weight); err == nil {
+		p.WeightKg = &v
+	}
+}
+
+// 0.71 m -> "2 ft 4 in"
+func formatImperialHeight(meters float64) string {
+	inches := math.Round(meters / metersPerInch)
+	feet := math.Floor(inches / 12)
+	inches -= feet * 12
+	if feet == 0 {
+		return fmt.Sprintf("%.0f in", inches)
+	}
+	return fmt.Sprintf("%.0f ft %.0f in", feet, inches)
+}
+
+// 6.9 kg -> "15.2 lb"
+func formatImperialWeight(kg float64) string {
+	return strconv.FormatFloat(math.Round(kg/kgPerPound*10)/10, 'f', -1, 64) + " lb"
+}
+
+// ইম্পেরিয়ালে মেট্রিক ফিল্ডের নাম -> রেসপন্সের ফিল্ডের নাম
+var imperialFields = map[string]string{
+	"height_m":  "height_in",
+	"weight_kg": "weight_lb",
+}
+
+// height_in -> height_m; ইম্পেরিয়াল ফিল্ড না হলে false
+func metricFieldFor(name string) (string, bool) {
+	for metric, imperial := range imperialFields {
+		if imperial == name {
+			return metric, true
+		}
+	}
+	return "", false
+}
+
+// ?units=imperial: প্রজেকশনের আগে পুরো রেকর্ড বদলায় -
+// height/weight ইম্পেরিয়াল স্ট্রিংয়ে, height_m/weight_kg এর জায়গায় height_in/weight_lb
+func toImperial(record map[string]interface{}, p *Pokemon) {
+	if p.HeightM != nil {
+		if _, ok := record["height"]; ok {
+			record["height"] = formatImperialHeight(*p.HeightM)
+		}
+		if _, ok := record["height_m"]; ok {
+			delete(record, "height_m")
+			record["height_in"] = math.Round(*p.HeightM/metersPerInch*10) / 10
+		}
+	}
+	if p.WeightKg != nil {
+		if _, ok := record["weight"]; ok {
+			record["weight"] = formatImperialWeight(*p.WeightKg)
+		}
+		if _, ok := record["weight_kg"]; ok {
+			delete(record, "weight_kg")
+			record["weight_lb"] = math.Round(*p.WeightKg/kgPerPound*10) / 10
+		}
+	}
+}
+
+// units প্যারামিটার: metric (ডিফল্ট) বা imperial
+func parseUnits(s string) (imperial bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "metric":
+		return false, nil
+	case "imperial":
+		return true, nil
+	}
+	return false, fmt.Errorf("unknown units %q, expected metric or imperial", s)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestImperialFieldProjection(t *testing.T) {
+	mux, _ := newServeMux()
+
+	get := func(url string) (int, []map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		var body struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		return rec.Code, body.Data
+	}
+
+	for _, fields := range []string{"height_m,weight_kg", "height_in,weight_lb"} {
+		code, data := get("/api/pokemons?units=imperial&fields=" + fields)
+		if code != http.StatusOK || len(data) == 0 {
+			t.Fatalf("fields=%s: status %d", fields, code)
+		}
+		got := data[0]
+		_, height := got["height_in"]
+		_, weight := got["weight_lb"]
+		if !height || !weight || len(got) != 2 {
+			t.Errorf("fields=%s: got %v, want only height_in and weight_lb", fields, got)
+		}
+	}
+
+	if code, _ := get("/api/pokemons?fields=height_in"); code != http.StatusBadRequest {
+		t.Errorf("fields=height_in without units=imperial: status %d, want 400", code)
+	}
+}
+
+// ?expand এর রেকর্ডও একই units ও fields পায়
+func TestExpandedRecordsAreShaped(t *testing.T) {
+	mux, _ := newServeMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/pokemons/1?units=imperial&expand=next_evolution&fields=name,height", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body)
+	}
+	var body struct {
+		Name          string                   `json:"name"`
+		NextEvolution []map[string]interface{} `json:"next_evolution"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.NextEvolution) == 0 {
+		t.Fatalf("no expanded records: %s", rec.Body)
+	}
+	for _, e := range body.NextEvolution {
+		if len(e) != 2 || e["name"] == nil || strings.HasSuffix(e["height"].(string), " m") {
+			t.Errorf("expanded record %v, want only name and an imperial height", e)
+		}
+	}
+}